@@ -20,13 +20,20 @@
 
 package ai_models
 
-import "GoTicTacToe/game"
+import (
+	"GoTicTacToe/game"
+	"time"
+)
 
 // AIModel defines the behavior required for an artificial intelligence
 // capable of playing the Tic-Tac-Toe game.
 //
 // Any AI strategy (random, minimax, etc.) must implement this interface
 // in order to be usable by the game engine.
+//
+// NextMove cannot be cancelled nor report failures. Models that can
+// additionally implement Engine (see engine.go); callers use Adapt to drive
+// any model through Engine.
 type AIModel interface {
 	// NextMove computes and returns the next move to play.
 	//
@@ -39,3 +46,16 @@ type AIModel interface {
 	// - x, y: coordinates of the chosen move on the board
 	NextMove(board *game.Board, me *game.Player, players []*game.Player) (x, y int)
 }
+
+// BudgetedAIModel is implemented by AI models able to limit their thinking
+// time.
+//
+// In timed matches the game engine calls NextMoveWithBudget instead of
+// NextMove, passing the share of the player's remaining clock time that the
+// model may spend on this move (see game.Clock.MoveBudget).
+type BudgetedAIModel interface {
+	AIModel
+
+	// NextMoveWithBudget behaves like NextMove but should return within budget.
+	NextMoveWithBudget(board *game.Board, me *game.Player, players []*game.Player, budget time.Duration) (x, y int)
+}
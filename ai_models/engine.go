@@ -0,0 +1,157 @@
+/**
+ ******************************************************************************
+ * @file            : engine.go
+ * @brief           : GoTicTacToe - Context-aware AI interface and adapter
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file defines Engine, the full AI interface: a search can be cancelled
+ * or given a deadline through a context, is bounded by Limits, and returns
+ * its move with an error and information about the search.
+ *
+ * AIModel remains the simple interface; Adapt turns any AIModel into an
+ * Engine so callers only deal with one of them.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AI errors.
+var (
+	// ErrNoMove is returned when the model found no move (full board, or a
+	// model unable to play the position).
+	ErrNoMove = errors.New("ai: no move available")
+
+	// ErrIllegalMove is wrapped when a model returns a move that cannot be
+	// played.
+	ErrIllegalMove = errors.New("ai: illegal move")
+)
+
+// Limits bounds a search. Zero fields leave the model's default.
+type Limits struct {
+	Budget   time.Duration // Thinking time (the context deadline also applies)
+	MaxDepth int           // Plies to search at most
+	MaxNodes int           // Positions to visit at most
+}
+
+// Info describes how a move was found. Fields a model does not report are
+// left zero.
+type Info struct {
+	Score int         // Score of the move for the AI player (model-specific scale)
+	Depth int         // Plies searched (last completed depth)
+	Nodes int         // Positions visited
+	PV    []game.Move // Principal variation, starting with the move
+	Time  time.Duration
+}
+
+// String returns a one-line summary of the search.
+func (i Info) String() string {
+	return fmt.Sprintf("score %d depth %d nodes %d pv %v (%s)", i.Score, i.Depth, i.Nodes, i.PV, i.Time.Round(time.Millisecond))
+}
+
+// Engine is implemented by AI models supporting cancellation, limits and
+// search reports.
+type Engine interface {
+	// Search computes the move of me on board.
+	//
+	// It must return promptly once ctx is done: with ctx.Err() if the search
+	// was cancelled, or with its best move so far if the context deadline
+	// passed (ctx.Err() if it has none yet). The returned move is always
+	// legal when err is nil.
+	Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) (game.Move, Info, error)
+}
+
+// Adapt returns model as an Engine: models implementing Engine are returned
+// as is, others are wrapped.
+//
+// The wrapper runs NextMove (or NextMoveWithBudget when a budget or a context
+// deadline is given and supported) on its own goroutine, turns the (-1, -1)
+// sentinel into ErrNoMove and rejects illegal moves.
+//
+// A budgeted model is given the time left until the context deadline, and its
+// move is awaited when the deadline passes. Otherwise, the wrapper gives up
+// as soon as ctx is done and returns its error: the model cannot be stopped
+// and keeps running in the background until NextMove returns, on a private
+// copy of the board, and its move is discarded.
+func Adapt(model AIModel) Engine {
+	if e, ok := model.(Engine); ok {
+		return e
+	}
+	return modelEngine{model: model}
+}
+
+// minDeadlineBudget is the budget given to a model when the context deadline
+// is (almost) reached, so that it still returns a move.
+const minDeadlineBudget = time.Millisecond
+
+// modelEngine adapts an AIModel to the Engine interface.
+type modelEngine struct {
+	model AIModel
+}
+
+// Search runs the wrapped model, giving up when ctx is done.
+func (e modelEngine) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) (game.Move, Info, error) {
+	if err := ctx.Err(); err != nil {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, err
+	}
+
+	start := time.Now()
+	budget := limits.Budget
+	if d, ok := ctx.Deadline(); ok && (budget <= 0 || d.Before(start.Add(budget))) {
+		budget = max(d.Sub(start), minDeadlineBudget)
+	}
+	budgeted, hasBudget := e.model.(BudgetedAIModel)
+	hasBudget = hasBudget && budget > 0
+
+	// The model gets its own board: it may outlive this call.
+	private := board.Clone()
+	result := make(chan game.Move, 1) // Buffered: the model may finish after we gave up
+	go func() {
+		var x, y int
+		if hasBudget {
+			x, y = budgeted.NextMoveWithBudget(private, me, players, budget)
+		} else {
+			x, y = e.model.NextMove(private, me, players)
+		}
+		result <- game.Move{X: x, Y: y}
+	}()
+
+	var m game.Move
+	select {
+	case m = <-result:
+	case <-ctx.Done():
+		// A budgeted model was told to be done by the deadline: wait for it.
+		if !hasBudget || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return game.Move{X: noMoveX, Y: noMoveY}, Info{}, ctx.Err()
+		}
+		m = <-result
+	}
+
+	info := Info{PV: []game.Move{m}, Time: time.Since(start)}
+	return m, info, checkMove(board, m)
+}
+
+// checkMove returns an error if m cannot be played on board.
+func checkMove(board *game.Board, m game.Move) error {
+	switch {
+	case m.X == noMoveX && m.Y == noMoveY:
+		return ErrNoMove
+	case m.X < 0 || m.Y < 0 || m.X >= board.Width || m.Y >= board.Height:
+		return fmt.Errorf("%w: %s is off the board", ErrIllegalMove, m)
+	case board.Cells[m.X][m.Y] != nil:
+		return fmt.Errorf("%w: %s is occupied", ErrIllegalMove, m)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/**
+ ******************************************************************************
+ * @file            : evaluate.go
+ * @brief           : GoTicTacToe - Heuristic evaluation for the Minimax search
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file scores positions where the search stops before the end of the
+ * game. Every window of ToWin aligned cells that holds the tokens of a single
+ * player is worth points to that player, growing with the number of tokens
+ * (length) and with the free cells on both sides of it (openness). The score
+ * is the difference between "me" and the opponent.
+ *
+ * A window missing a single token of the player to move is a win on the next
+ * move and scores as such.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import "GoTicTacToe/game"
+
+// Evaluation weights.
+const (
+	// windowBase is the growth factor of a window's value per token.
+	windowBase = 4
+
+	// openEndBonus multiplies a window's value for each free cell extending
+	// it at either end.
+	openEndBonus = 1
+)
+
+// evalDirections are the four line directions (the other four are the same
+// lines scanned backwards).
+var evalDirections = [...]game.Direction{
+	{DX: 1, DY: 0},
+	{DX: 0, DY: 1},
+	{DX: 1, DY: 1},
+	{DX: 1, DY: -1},
+}
+
+// evaluate returns the heuristic score of the current position from the
+// point of view of "me", with toMove to play next.
+//
+// The result is scoreWin or scoreLoss when toMove completes a line on its
+// next move, and otherwise lies strictly between them.
+func (s *search) evaluate(toMove *game.Player) int {
+	b := s.board
+	length := b.WinLength()
+
+	score := 0
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			for _, dir := range evalDirections {
+				endX, endY := x+dir.DX*(length-1), y+dir.DY*(length-1)
+				if endX < 0 || endY < 0 || endX >= b.Width || endY >= b.Height {
+					continue
+				}
+
+				owner, stones := windowOwner(b, x, y, dir, length)
+				if owner == nil || stones == 0 {
+					continue
+				}
+				if owner == toMove && stones == length-1 {
+					if toMove == s.me {
+						return scoreWin
+					}
+					return scoreLoss
+				}
+
+				value := windowValue(stones)
+				value += value * openEndBonus * openEnds(b, x, y, dir, length)
+				if owner == s.me {
+					score += value
+				} else {
+					score -= value
+				}
+			}
+		}
+	}
+
+	// Heuristic scores must never be mistaken for a finished game.
+	return min(max(score, scoreLoss+1), scoreWin-1)
+}
+
+// windowOwner returns the only player with tokens in the window of length
+// cells starting at (x, y) in direction dir, and their number of tokens.
+// The owner is nil if the window is empty or holds tokens of several players.
+func windowOwner(b *game.Board, x, y int, dir game.Direction, length int) (*game.Player, int) {
+	var owner *game.Player
+	stones := 0
+	for step := 0; step < length; step++ {
+		c := b.Cells[x+dir.DX*step][y+dir.DY*step]
+		if c == nil {
+			continue
+		}
+		if owner != nil && c != owner {
+			return nil, 0
+		}
+		owner = c
+		stones++
+	}
+	return owner, stones
+}
+
+// openEnds returns how many of the two cells just outside the window are on
+// the board and empty.
+func openEnds(b *game.Board, x, y int, dir game.Direction, length int) int {
+	open := 0
+	for _, end := range [...]game.Move{
+		{X: x - dir.DX, Y: y - dir.DY},
+		{X: x + dir.DX*length, Y: y + dir.DY*length},
+	} {
+		if end.X >= 0 && end.Y >= 0 && end.X < b.Width && end.Y < b.Height && b.Cells[end.X][end.Y] == nil {
+			open++
+		}
+	}
+	return open
+}
+
+// windowValue returns the base value of a window holding stones tokens.
+func windowValue(stones int) int {
+	value := 1
+	for range stones {
+		value *= windowBase
+	}
+	return value
+}
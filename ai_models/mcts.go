@@ -0,0 +1,378 @@
+/**
+ ******************************************************************************
+ * @file            : mcts.go
+ * @brief           : GoTicTacToe - Monte Carlo Tree Search AI implementation
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains an AI based on Monte Carlo Tree Search with the UCT
+ * selection rule. Each iteration walks down the tree (balancing the best
+ * moves found so far against rarely tried ones), adds one new position,
+ * finishes the game with a fast playout policy and credits the result to
+ * every move on the path.
+ *
+ * Unlike MinimaxAI it needs no evaluation function and works for any number
+ * of players: each move is credited with the result of the player who made
+ * it. The visit counts of the root moves are available through Analyze.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// MCTS defaults and scoring.
+const (
+	// DefaultExploration is the UCT exploration constant (sqrt(2), the
+	// theoretical value for rewards in [0, 1]).
+	DefaultExploration = math.Sqrt2
+
+	// Rewards of a finished playout for one player.
+	rewardWin  = 1.0
+	rewardLoss = 0.0
+
+	// scorePerMille scales the win rate reported in Info.Score.
+	scorePerMille = 1000
+
+	// iterationCheckInterval is the number of iterations between two clock
+	// and context checks.
+	iterationCheckInterval = 64
+)
+
+// MCTSAI is an AI player using Monte Carlo Tree Search (UCT).
+//
+// The search runs until Playouts iterations are done or Budget has elapsed,
+// whichever comes first; with neither set, it uses DefaultMoveBudget. The
+// most visited move is played.
+type MCTSAI struct {
+	Playouts    int           // Iterations to run (0 = limited by time only)
+	Budget      time.Duration // Thinking time (0 = DefaultMoveBudget unless Playouts is set)
+	Exploration float64       // UCT exploration constant (0 = DefaultExploration)
+	Policy      PlayoutPolicy // Playout policy (nil = HeuristicPlayout)
+	Seed        int64         // Random seed (0 = seeded from the clock)
+}
+
+// MoveVisits are the search statistics of a root move.
+type MoveVisits struct {
+	Move    game.Move
+	Visits  int     // Iterations that went through the move
+	WinRate float64 // Average reward of the AI player after the move, in [0, 1]
+}
+
+// NextMove returns the most visited move (x, y) after the search.
+func (ai MCTSAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	return ai.NextMoveWithBudget(board, me, players, 0)
+}
+
+// NextMoveWithBudget behaves like NextMove but searches for budget (0 uses
+// the AI's own limits).
+func (ai MCTSAI) NextMoveWithBudget(board *game.Board, me *game.Player, players []*game.Player, budget time.Duration) (int, int) {
+	m, _, err := ai.Search(context.Background(), board, me, players, Limits{Budget: budget})
+	if err != nil {
+		return noMoveX, noMoveY
+	}
+	return m.X, m.Y
+}
+
+// Search implements Engine. limits.MaxNodes caps the number of playouts and
+// limits.MaxDepth is ignored.
+func (ai MCTSAI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) (game.Move, Info, error) {
+	visits, info, err := ai.Analyze(ctx, board, me, players, limits)
+	if len(visits) == 0 {
+		if err == nil {
+			err = ErrNoMove
+		}
+		return game.Move{X: noMoveX, Y: noMoveY}, info, err
+	}
+	return visits[0].Move, info, err
+}
+
+// Analyze runs the search and returns the statistics of every root move,
+// most visited first, e.g. to display them on the board.
+func (ai MCTSAI) Analyze(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) ([]MoveVisits, Info, error) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		return nil, Info{}, err
+	}
+
+	t := ai.newTree(board, me, players)
+	if len(t.root.untried) == 0 {
+		return nil, Info{}, ErrNoMove
+	}
+
+	playouts := limits.MaxNodes
+	if playouts == 0 {
+		playouts = ai.Playouts
+	}
+	budget := limits.Budget
+	if budget == 0 {
+		budget = ai.Budget
+	}
+	if budget == 0 && playouts == 0 {
+		budget = DefaultMoveBudget
+	}
+	var deadline time.Time
+	if budget > 0 {
+		deadline = start.Add(budget)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+
+	iterations := 0
+	for playouts == 0 || iterations < playouts {
+		if iterations%iterationCheckInterval == 0 && iterations > 0 {
+			if ctx.Err() != nil || (!deadline.IsZero() && time.Now().After(deadline)) {
+				break
+			}
+		}
+		t.iterate()
+		iterations++
+	}
+
+	visits := t.rootVisits()
+	info := Info{
+		Score: int(visits[0].WinRate * scorePerMille),
+		Depth: t.maxDepth,
+		Nodes: iterations,
+		PV:    t.principalVariation(),
+		Time:  time.Since(start),
+	}
+	if err := ctx.Err(); errors.Is(err, context.Canceled) {
+		return visits, info, err
+	}
+	return visits, info, nil
+}
+
+// mctsNode is a position of the search tree, reached by move.
+type mctsNode struct {
+	move     game.Move
+	mover    int // Index of the player who played move
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []game.Move // Moves not expanded yet
+
+	visits int
+	reward float64 // Sum of the mover's rewards
+
+	// A terminal node ends the game: winner is the mover's index, or -1
+	// for a draw.
+	terminal bool
+	winner   int
+}
+
+// mctsTree holds the state of a search.
+type mctsTree struct {
+	board   *game.Board // Scratch board, modified with Play and Undo
+	players []*game.Player
+	me      int // Index of the AI player
+	root    *mctsNode
+
+	exploration float64
+	policy      PlayoutPolicy
+	rng         *rand.Rand
+	maxDepth    int
+	path        []game.Move // Moves played on board during an iteration
+}
+
+// newTree prepares a search from board with me to move.
+func (ai MCTSAI) newTree(board *game.Board, me *game.Player, players []*game.Player) *mctsTree {
+	seed := ai.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	t := &mctsTree{
+		board:       board.Clone(),
+		players:     players,
+		me:          playerIndex(players, me),
+		exploration: ai.Exploration,
+		policy:      ai.Policy,
+		rng:         rand.New(rand.NewSource(seed)),
+	}
+	if t.exploration == 0 {
+		t.exploration = DefaultExploration
+	}
+	if t.policy == nil {
+		t.policy = HeuristicPlayout{}
+	}
+
+	// The root "move" was made by the previous player.
+	prev := (t.me + len(players) - 1) % len(players)
+	t.root = &mctsNode{move: noKiller, mover: prev, winner: -1}
+	t.root.untried = t.shuffled(t.board.AvailableMoves())
+	return t
+}
+
+// iterate runs one selection, expansion, playout and backpropagation.
+func (t *mctsTree) iterate() {
+	t.path = t.path[:0]
+
+	// Selection: follow UCT while the node is fully expanded.
+	n := t.root
+	for !n.terminal && len(n.untried) == 0 && len(n.children) > 0 {
+		n = t.selectChild(n)
+		t.play(n)
+	}
+
+	// Expansion: add one untried move.
+	if !n.terminal && len(n.untried) > 0 {
+		n = t.expand(n)
+	}
+	t.maxDepth = max(t.maxDepth, len(t.path))
+
+	// Playout: finish the game from the new position.
+	winner := n.winner
+	if !n.terminal {
+		winner = t.playout((n.mover + 1) % len(t.players))
+	}
+
+	// Backpropagation, then restore the board.
+	for ; n != nil; n = n.parent {
+		n.visits++
+		n.reward += t.reward(n.mover, winner)
+	}
+	for i := len(t.path) - 1; i >= 0; i-- {
+		t.board.Undo(t.path[i].X, t.path[i].Y)
+	}
+}
+
+// selectChild returns the child of n with the highest UCT value.
+func (t *mctsTree) selectChild(n *mctsNode) *mctsNode {
+	logVisits := math.Log(float64(n.visits))
+
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	for _, c := range n.children {
+		value := c.reward/float64(c.visits) + t.exploration*math.Sqrt(logVisits/float64(c.visits))
+		if value > bestValue {
+			best, bestValue = c, value
+		}
+	}
+	return best
+}
+
+// expand adds the child of n for its next untried move and plays it.
+func (t *mctsTree) expand(n *mctsNode) *mctsNode {
+	m := n.untried[len(n.untried)-1]
+	n.untried = n.untried[:len(n.untried)-1]
+
+	mover := (n.mover + 1) % len(t.players)
+	c := &mctsNode{move: m, mover: mover, parent: n, winner: -1}
+	c.terminal = t.board.WinsWith(t.players[mover], m.X, m.Y)
+	if c.terminal {
+		c.winner = mover
+	}
+	n.children = append(n.children, c)
+
+	t.play(c)
+	if !c.terminal {
+		if moves := t.board.AvailableMoves(); len(moves) == 0 {
+			c.terminal = true
+		} else {
+			c.untried = t.shuffled(moves)
+		}
+	}
+	return c
+}
+
+// play plays the move of n on the scratch board.
+func (t *mctsTree) play(n *mctsNode) {
+	t.board.Play(t.players[n.mover], n.move.X, n.move.Y)
+	t.path = append(t.path, n.move)
+}
+
+// playout finishes the game with the playout policy, starting with the
+// player at index turn, and returns the winner's index (-1 for a draw).
+// The moves are undone with the rest of the iteration.
+func (t *mctsTree) playout(turn int) int {
+	for {
+		p := t.players[turn]
+		m, ok := t.policy.Move(t.board, p, t.players, t.rng)
+		if !ok {
+			return -1
+		}
+
+		won := t.board.WinsWith(p, m.X, m.Y)
+		t.board.Play(p, m.X, m.Y)
+		t.path = append(t.path, m)
+		if won {
+			return turn
+		}
+		turn = (turn + 1) % len(t.players)
+	}
+}
+
+// reward returns the reward of player for a game won by winner (-1 for a
+// draw, shared equally).
+func (t *mctsTree) reward(player, winner int) float64 {
+	switch winner {
+	case -1:
+		return rewardWin / float64(len(t.players))
+	case player:
+		return rewardWin
+	default:
+		return rewardLoss
+	}
+}
+
+// rootVisits returns the statistics of the root moves, most visited first.
+func (t *mctsTree) rootVisits() []MoveVisits {
+	visits := make([]MoveVisits, 0, len(t.root.children))
+	for _, c := range t.root.children {
+		mv := MoveVisits{Move: c.move, Visits: c.visits}
+		if c.visits > 0 {
+			mv.WinRate = c.reward / float64(c.visits)
+		}
+		visits = append(visits, mv)
+	}
+	sort.SliceStable(visits, func(i, j int) bool {
+		return visits[i].Visits > visits[j].Visits
+	})
+	return visits
+}
+
+// principalVariation follows the most visited child from the root.
+func (t *mctsTree) principalVariation() []game.Move {
+	var pv []game.Move
+	for n := t.root; len(n.children) > 0; {
+		best := n.children[0]
+		for _, c := range n.children[1:] {
+			if c.visits > best.visits {
+				best = c
+			}
+		}
+		pv = append(pv, best.move)
+		n = best
+	}
+	return pv
+}
+
+// shuffled shuffles moves in place and returns them.
+func (t *mctsTree) shuffled(moves []game.Move) []game.Move {
+	t.rng.Shuffle(len(moves), func(i, j int) {
+		moves[i], moves[j] = moves[j], moves[i]
+	})
+	return moves
+}
+
+// playerIndex returns the index of p in players (0 if absent).
+func playerIndex(players []*game.Player, p *game.Player) int {
+	for i, candidate := range players {
+		if candidate == p {
+			return i
+		}
+	}
+	return 0
+}
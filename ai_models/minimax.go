@@ -1,117 +1,514 @@
 package ai_models
 
-import "GoTicTacToe/game"
+import (
+	"GoTicTacToe/game"
+	"context"
+	"errors"
+	"time"
+)
 
 // MinimaxAI is an AI player using the Minimax algorithm.
 // It is designed for two-player, deterministic, perfect-information games
 // such as Tic-Tac-Toe.
 //
 // In the classic 3x3 Tic-Tac-Toe, this strategy is unbeatable (optimal play).
-type MinimaxAI struct{}
+//
+// The search uses alpha-beta pruning with move ordering (see ordering.go),
+// which returns the same moves as a plain Minimax while visiting a small
+// fraction of the tree.
+//
+// Searched positions are remembered in a transposition table shared across
+// NextMove calls, so positions reached through different move orders, or
+// already analysed on a previous turn, are not searched again.
+//
+// The search deepens one ply at a time until the end of the game or until its
+// budget runs out, and plays the best move of the last completed depth.
+// Positions at the depth limit are scored by a heuristic evaluation (see
+// evaluate.go). Small boards are searched to the end well within the budget,
+// so the result there is the exact Minimax move.
+//
+// Before the general search, a threat-space search (see threats.go) looks
+// for a forced win made of threats, which it plays at once, and for such a
+// win of the opponent, in which case only the moves refuting it are
+// searched.
+//
+// MinimaxAI implements Engine: Search reports the score, depth, node count
+// and principal variation of the last completed depth, and stops as soon as
+// its context is cancelled.
+//
+// The search runs on several workers sharing the transposition table (see
+// parallel.go). With a single worker, a fresh table and a depth or node
+// limit instead of a time budget, the search is deterministic.
+type MinimaxAI struct {
+	Table *TranspositionTable // Table to use (DefaultTranspositionTable if nil)
+
+	// Budget is the thinking time per move: 0 uses DefaultMoveBudget, a
+	// negative value disables the time limit.
+	Budget time.Duration
+
+	// MaxNodes stops the search after visiting this many positions
+	// (0 = no node limit).
+	MaxNodes int
+
+	// ThreatDepth is the number of plies of the threat-space pass:
+	// 0 uses DefaultThreatDepth, a negative value disables the pass.
+	ThreatDepth int
+
+	// Workers is the number of goroutines searching in parallel. 1 or less
+	// searches on the calling goroutine only, with reproducible results;
+	// DefaultWorkers uses every available CPU.
+	Workers int
+}
+
+// DefaultMoveBudget is the thinking time of a MinimaxAI without Budget.
+const DefaultMoveBudget = 500 * time.Millisecond
 
 // Minimax evaluation scores.
 //
-// These constants are intentionally kept small and symmetric:
-// - win  => +1
-// - loss => -1
+// Terminal scores are symmetric:
+// - win  => +scoreWin
+// - loss => -scoreWin
 // - draw =>  0
 //
-// Sentinel values are used as initial "worst possible" bounds when searching.
+// Heuristic scores of non-terminal positions always lie strictly between
+// scoreLoss and scoreWin. Sentinel values are used as initial "worst
+// possible" bounds when searching.
 const (
-	scoreWin  = 1
+	scoreWin  = 1_000_000
 	scoreDraw = 0
-	scoreLoss = -1
+	scoreLoss = -scoreWin
 
 	// initialLowerBound is used to initialize the best score in maximizing turns.
 	// It must be strictly lower than the minimal possible score (scoreLoss).
-	initialLowerBound = -9999
+	initialLowerBound = scoreLoss - 1
 
 	// initialUpperBound is used to initialize the best score in minimizing turns.
 	// It must be strictly higher than the maximal possible score (scoreWin).
-	initialUpperBound = 9999
+	initialUpperBound = scoreWin + 1
+
+	// nodeCheckInterval is the number of nodes between two clock checks.
+	nodeCheckInterval = 1024
 )
 
 // NextMove returns the best move (x, y) for the current player according to Minimax.
 //
-// The current implementation supports two-player games only.
-// If the number of players is not exactly two, it falls back to RandomAI
-// to avoid undefined behavior (e.g., "opponent" not well-defined).
-func (MinimaxAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+// Minimax is defined for two players: with any other number of players,
+// the move is searched by MultiplayerAI in paranoid mode instead.
+//
+// Among equally good moves, the first one in UniqueMoves order is returned,
+// whatever order the search explores them in.
+func (ai MinimaxAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	return ai.NextMoveWithBudget(board, me, players, 0)
+}
+
+// NextMoveWithBudget behaves like NextMove but stops deepening once budget
+// has elapsed (0 uses the AI's Budget, a negative budget disables the time
+// limit).
+func (ai MinimaxAI) NextMoveWithBudget(board *game.Board, me *game.Player, players []*game.Player, budget time.Duration) (int, int) {
+	m, _, err := ai.Search(context.Background(), board, me, players, Limits{Budget: budget})
+	if err != nil {
+		return noMoveX, noMoveY
+	}
+	return m.X, m.Y
+}
+
+// Search implements Engine.
+//
+// Zero limits fall back to the AI's Budget and MaxNodes; a negative
+// limits.Budget disables the time limit. The context deadline, when
+// earlier, ends the search like the budget does. Cancelling the context
+// returns the best move so far along with the context error.
+func (ai MinimaxAI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) (game.Move, Info, error) {
+	start := time.Now()
 	if len(players) != 2 {
-		return RandomAI{}.NextMove(board, me, players)
+		mp := MultiplayerAI{Mode: MODE_PARANOID, Budget: ai.Budget, MaxNodes: ai.MaxNodes}
+		return mp.Search(ctx, board, me, players, limits)
 	}
 
-	bestScore := initialLowerBound
-	bestMove := game.Move{X: -1, Y: -1}
+	table := ai.Table
+	if table == nil {
+		table = DefaultTranspositionTable()
+	}
+	table.NewSearch()
+	s := newSearch(board, me, players, table)
+	s.ctx = ctx
 
-	for _, mv := range board.AvailableMoves() {
-		clone := board.Clone()
-		clone.Play(me, mv.X, mv.Y)
+	budget := limits.Budget
+	if budget == 0 {
+		budget = ai.Budget
+	}
+	if budget == 0 {
+		budget = DefaultMoveBudget
+	}
+	if budget > 0 {
+		s.deadline = start.Add(budget)
+	}
+	if d, ok := ctx.Deadline(); ok && (s.deadline.IsZero() || d.Before(s.deadline)) {
+		s.deadline = d
+	}
 
-		score := minimax(clone, me, players, false)
+	s.maxNodes = limits.MaxNodes
+	if s.maxNodes == 0 {
+		s.maxNodes = ai.MaxNodes
+	}
+	maxDepth := s.empty
+	if limits.MaxDepth > 0 {
+		maxDepth = min(maxDepth, limits.MaxDepth)
+	}
 
-		if score > bestScore {
-			bestScore = score
-			bestMove = mv
+	// Symmetric moves have the same value: only search one of each class.
+	candidates := board.UniqueMoves()
+	if len(candidates) == 0 {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, ErrNoMove
+	}
+
+	var info Info
+	if ai.ThreatDepth >= 0 {
+		line, safe := ai.threatPass(ctx, board, me, s.opp, candidates, budget, &info)
+		if line != nil {
+			info.Score, info.Depth, info.PV = scoreWin, len(line), line
+			info.Time = time.Since(start)
+			return line[0], info, nil
 		}
+		candidates = safe
+	}
+
+	// Until a depth completes, play the most promising move.
+	hashMove := noKiller
+	if e, ok := table.Probe(positionKey(s.board, me, me)); ok {
+		hashMove = e.Move
+	}
+	first := candidates[s.order(candidates, me, 0, hashMove)[0]]
+
+	var result rootResult
+	if workers := ai.workers(); workers > 1 {
+		result = s.deepenParallel(candidates, maxDepth, first, players, workers)
+	} else {
+		result = s.deepen(candidates, 1, maxDepth, first)
+	}
+	bestMove := result.move
+	if result.depth > 0 {
+		info.Score, info.Depth = result.score, result.depth
 	}
 
-	return bestMove.X, bestMove.Y
+	info.Nodes += s.nodes
+	info.PV = s.principalVariation(bestMove, max(info.Depth, 1))
+	info.Time = time.Since(start)
+
+	if err := ctx.Err(); errors.Is(err, context.Canceled) {
+		return bestMove, info, err
+	}
+	return bestMove, info, nil
 }
 
-// minimax recursively evaluates the game tree from the perspective of "me".
-//
-// Parameters:
-// - board: current board state
-// - me: the player for which we are computing the best outcome
-// - players: list of players (expected length: 2)
-// - maximizing: true if it's "me" turn, false if it's opponent's turn
+// workers returns the number of search workers to use.
+func (ai MinimaxAI) workers() int {
+	return max(ai.Workers, 1)
+}
+
+// threatPass runs the threat-space pass with a share of budget (see
+// threatSpacePass) and adds its nodes to info.
+func (ai MinimaxAI) threatPass(ctx context.Context, board *game.Board, me, opp *game.Player, candidates []game.Move, budget time.Duration, info *Info) ([]game.Move, []game.Move) {
+	depth := ai.ThreatDepth
+	if depth == 0 {
+		depth = DefaultThreatDepth
+	}
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget/threatBudgetShare)
+		defer cancel()
+	}
+
+	line, safe, nodes := threatSpacePass(ctx, board, me, opp, candidates, depth)
+	info.Nodes += nodes
+	return line, safe
+}
+
+// search holds the state of a single alpha-beta search from the point of
+// view of "me".
 //
-// Returns an integer score among {scoreWin, scoreDraw, scoreLoss}.
+// The board is a private copy, modified in place with Play and Undo.
+type search struct {
+	board *game.Board
+	me    *game.Player
+	opp   *game.Player
+	empty int // Empty cells left on board
+
+	table   *TranspositionTable      // Results of searched positions
+	killers [][killerSlots]game.Move // Recent cutoff moves, per ply
+	history [][]int                  // Cutoff counts weighted by depth, per cell
+
+	ctx      context.Context // Cancels the search when done
+	deadline time.Time       // Time to stop searching (zero = no limit)
+	maxNodes int             // Nodes to stop after (0 = no limit)
+	nodes    int             // Nodes visited so far
+	aborted  bool            // Whether the budget ran out or ctx is done
+
+	shared *sharedSearch // State shared with the other workers (nil if alone)
+}
+
+// newSearch prepares a search on a copy of board.
+func newSearch(board *game.Board, me *game.Player, players []*game.Player, table *TranspositionTable) *search {
+	s := &search{
+		board:   board.Clone(),
+		me:      me,
+		opp:     me.Opponent(players),
+		empty:   board.EmptyCount(),
+		table:   table,
+		ctx:     context.Background(),
+		history: make([][]int, board.Width),
+	}
+	for x := range s.history {
+		s.history[x] = make([]int, board.Height)
+	}
+
+	s.killers = make([][killerSlots]game.Move, s.empty+1)
+	for ply := range s.killers {
+		for slot := range s.killers[ply] {
+			s.killers[ply][slot] = noKiller
+		}
+	}
+	return s
+}
+
+// rootResult is the outcome of an iterative deepening search.
+type rootResult struct {
+	move  game.Move // Best move of the last completed depth
+	score int       // Score of move
+	depth int       // Last completed depth (0 if none)
+}
+
+// deepen searches candidates one ply deeper at a time, from depth start to
+// maxDepth, until the budget runs out. Each depth starts with the best move
+// of the previous one, first for the first depth.
+func (s *search) deepen(candidates []game.Move, start, maxDepth int, first game.Move) rootResult {
+	rootKey := positionKey(s.board, s.me, s.me)
+	result := rootResult{move: first}
+
+	for depth := start; depth <= maxDepth; depth++ {
+		mv, score, ok := s.searchRoot(candidates, depth, result.move)
+		if !ok {
+			break
+		}
+		result = rootResult{move: mv, score: score, depth: depth}
+		s.table.Store(rootKey, depth, BOUND_EXACT, score, mv)
+	}
+	return result
+}
+
+// searchRoot searches every candidate depth plies deep, starting with
+// first, and returns the best one with its score.
 //
-// This version does not implement alpha-beta pruning (which would speed up search),
-// because Tic-Tac-Toe's state space is small. The code remains simple and readable.
-func minimax(board *game.Board, me *game.Player, players []*game.Player, maximizing bool) int {
-	// Terminal states: win/loss/draw
-	winner := board.CheckWin()
-	if winner == me {
-		return scoreWin
+// It returns false if the budget ran out before the depth was completed.
+func (s *search) searchRoot(candidates []game.Move, depth int, first game.Move) (game.Move, int, bool) {
+	bestScore := initialLowerBound
+	bestIndex := len(candidates)
+	bestMove := first
+
+	for _, i := range s.order(candidates, s.me, 0, first) {
+		// A move searched late must still win ties against the moves that
+		// come after it in UniqueMoves order: lower the bound by one so an
+		// equal score is computed exactly instead of being pruned.
+		alpha := bestScore
+		if i < bestIndex {
+			alpha = bestScore - 1
+		}
+
+		score := s.play(s.me, candidates[i], 0, depth, alpha, initialUpperBound)
+		if s.aborted {
+			return bestMove, bestScore, false
+		}
+		if score > bestScore || (score == bestScore && i < bestIndex) {
+			bestScore, bestIndex, bestMove = score, i, candidates[i]
+		}
 	}
-	if winner != nil && winner != me {
+	return bestMove, bestScore, true
+}
+
+// play evaluates the position after p plays mv at the given ply, searching
+// depth plies (including mv) within the window (alpha, beta).
+//
+// Returns scoreWin, scoreDraw or scoreLoss for finished games, and a
+// heuristic score in between when the depth limit is reached first. Scores
+// outside the window are bounds: a result <= alpha means "at most alpha",
+// a result >= beta means "at least beta".
+func (s *search) play(p *game.Player, mv game.Move, ply, depth, alpha, beta int) int {
+	// Terminal states: the move wins, or fills the last cell.
+	if s.board.WinsWith(p, mv.X, mv.Y) {
+		if p == s.me {
+			return scoreWin
+		}
 		return scoreLoss
 	}
-	if board.CheckDraw() {
+	if s.empty == 1 {
+		return scoreDraw
+	}
+
+	next := s.me
+	if p == s.me {
+		next = s.opp
+	}
+
+	s.board.Play(p, mv.X, mv.Y)
+	s.empty--
+	var score int
+	if depth <= 1 {
+		s.visit()
+		score = s.evaluate(next)
+	} else {
+		score = s.alphaBeta(ply+1, depth-1, next == s.me, alpha, beta)
+	}
+	s.empty++
+	s.board.Undo(mv.X, mv.Y)
+	return score
+}
+
+// visit counts a node and checks the budget every nodeCheckInterval nodes.
+func (s *search) visit() {
+	s.nodes++
+	if s.maxNodes > 0 && s.nodes >= s.maxNodes {
+		s.aborted = true
+	}
+	if s.nodes%nodeCheckInterval != 0 {
+		return
+	}
+	if s.ctx.Err() != nil || (!s.deadline.IsZero() && time.Now().After(s.deadline)) {
+		s.aborted = true
+	}
+	if s.shared != nil && s.shared.poll(nodeCheckInterval) {
+		s.aborted = true
+	}
+}
+
+// principalVariation returns the expected line of play starting with first,
+// at most depth plies long, following the best moves stored in the
+// transposition table.
+func (s *search) principalVariation(first game.Move, depth int) []game.Move {
+	var pv []game.Move
+	p, m := s.me, first
+	for len(pv) < depth && m != noKiller && s.board.Cells[m.X][m.Y] == nil {
+		pv = append(pv, m)
+		won := s.board.WinsWith(p, m.X, m.Y)
+		s.board.Play(p, m.X, m.Y)
+		if won {
+			break
+		}
+
+		if p == s.me {
+			p = s.opp
+		} else {
+			p = s.me
+		}
+		e, ok := s.table.Probe(positionKey(s.board, s.me, p))
+		if !ok {
+			break
+		}
+		m = e.Move
+	}
+
+	for i := len(pv) - 1; i >= 0; i-- {
+		s.board.Undo(pv[i].X, pv[i].Y)
+	}
+	return pv
+}
+
+// alphaBeta returns the score of the current position searched depth plies
+// deep, where it is "me" turn if maximizing is true and the opponent's turn
+// otherwise.
+//
+// The transposition table is consulted first: a stored result for the same
+// position, searched at least as deep, can settle it or narrow the window,
+// and its best move is searched first. Moves are then explored best-first
+// (see order) and the search stops as soon as the window closes; the move
+// that caused the cutoff feeds the killer and history heuristics.
+//
+// Once the budget has run out the result is meaningless and is neither
+// stored nor used by the caller.
+func (s *search) alphaBeta(ply, depth int, maximizing bool, alpha, beta int) int {
+	s.visit()
+	if s.aborted {
 		return scoreDraw
 	}
 
-	// Maximizing: it's "me" turn.
+	p := s.opp
 	if maximizing {
-		best := initialLowerBound
-		for _, mv := range board.AvailableMoves() {
-			clone := board.Clone()
-			clone.Play(me, mv.X, mv.Y)
+		p = s.me
+	}
 
-			score := minimax(clone, me, players, false)
-			if score > best {
-				best = score
+	// Past the end of the game, every depth is the same complete search.
+	depth = min(depth, s.empty)
+
+	key := positionKey(s.board, s.me, p)
+	hashMove := noKiller
+	if e, ok := s.table.Probe(key); ok {
+		hashMove = e.Move
+		if e.Depth >= depth {
+			switch e.Bound {
+			case BOUND_EXACT:
+				return e.Score
+			case BOUND_LOWER:
+				alpha = max(alpha, e.Score)
+			case BOUND_UPPER:
+				beta = min(beta, e.Score)
+			}
+			if alpha >= beta {
+				return e.Score
 			}
 		}
-		return best
 	}
+	alphaOrig, betaOrig := alpha, beta
 
-	// Minimizing: opponent turn.
-	opp := me.Opponent(players)
-	best := initialUpperBound
-
-	for _, mv := range board.AvailableMoves() {
-		clone := board.Clone()
-		clone.Play(opp, mv.X, mv.Y)
+	moves := s.board.AvailableMoves()
+	bestMove := noKiller
 
-		score := minimax(clone, me, players, true)
-		if score < best {
-			best = score
+	var best int
+	if maximizing {
+		// Maximizing: it's "me" turn.
+		best = initialLowerBound
+		for _, i := range s.order(moves, p, ply, hashMove) {
+			score := s.play(p, moves[i], ply, depth, alpha, beta)
+			if s.aborted {
+				return scoreDraw
+			}
+			if score > best {
+				best, bestMove = score, moves[i]
+			}
+			if best > alpha {
+				alpha = best
+			}
+			if alpha >= beta || best == scoreWin {
+				s.cutoff(moves[i], ply, depth)
+				break
+			}
+		}
+	} else {
+		// Minimizing: opponent turn.
+		best = initialUpperBound
+		for _, i := range s.order(moves, p, ply, hashMove) {
+			score := s.play(p, moves[i], ply, depth, alpha, beta)
+			if s.aborted {
+				return scoreDraw
+			}
+			if score < best {
+				best, bestMove = score, moves[i]
+			}
+			if best < beta {
+				beta = best
+			}
+			if alpha >= beta || best == scoreLoss {
+				s.cutoff(moves[i], ply, depth)
+				break
+			}
 		}
 	}
 
+	bound := BOUND_EXACT
+	switch {
+	case best <= alphaOrig:
+		bound = BOUND_UPPER
+	case best >= betaOrig:
+		bound = BOUND_LOWER
+	}
+	s.table.Store(key, depth, bound, best, bestMove)
 	return best
 }
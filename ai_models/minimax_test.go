@@ -0,0 +1,175 @@
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// testTableBytes is the size of the transposition tables used by the tests.
+const testTableBytes = 1 << 20
+
+// exactMinimax returns a MinimaxAI searching to the end of the game on a
+// single worker, without the threat-space pass, so that its result can be
+// compared with a plain Minimax.
+func exactMinimax(table *TranspositionTable) MinimaxAI {
+	return MinimaxAI{Table: table, Budget: -1, ThreatDepth: -1, Workers: 1}
+}
+
+// memoKey identifies a position and the side to move in plainMinimax.
+type memoKey struct {
+	hash   uint64
+	toMove int
+}
+
+// plainMinimax returns the Minimax value for me of the position on b with
+// toMove to play (scoreWin, scoreDraw or scoreLoss), searching every move
+// without pruning. Values are cached in memo unless it is nil.
+func plainMinimax(b *game.Board, me, toMove, other *game.Player, memo map[memoKey]int) int {
+	key := memoKey{b.Hash(), toMove.ID}
+	if v, ok := memo[key]; ok {
+		return v
+	}
+
+	best := initialUpperBound
+	if toMove == me {
+		best = initialLowerBound
+	}
+	for _, m := range b.AvailableMoves() {
+		v := plainMove(b, me, toMove, other, m, memo)
+		if toMove == me {
+			best = max(best, v)
+		} else {
+			best = min(best, v)
+		}
+	}
+
+	if memo != nil {
+		memo[key] = best
+	}
+	return best
+}
+
+// plainMove returns the Minimax value for me of toMove playing m.
+func plainMove(b *game.Board, me, toMove, other *game.Player, m game.Move, memo map[memoKey]int) int {
+	switch {
+	case b.WinsWith(toMove, m.X, m.Y) && toMove == me:
+		return scoreWin
+	case b.WinsWith(toMove, m.X, m.Y):
+		return scoreLoss
+	case b.EmptyCount() == 1:
+		return scoreDraw
+	}
+
+	b.Play(toMove, m.X, m.Y)
+	v := plainMinimax(b, me, other, toMove, memo)
+	b.Undo(m.X, m.Y)
+	return v
+}
+
+// randomPosition plays random moves from the empty board until it holds at
+// least minTokens tokens (and a random number more), and returns the game if
+// it is still in progress with at least one empty cell.
+func randomPosition(rng *rand.Rand, width, height, toWin, minTokens int) *game.Game {
+	for {
+		g := game.NewGameWithConfig(width, height, toWin, nil)
+		tokens := minTokens + rng.Intn(width*height-minTokens)
+		for len(g.History) < tokens && g.State == game.PLAYING {
+			moves := g.Board.AvailableMoves()
+			m := moves[rng.Intn(len(moves))]
+			g.PlayMove(m.X, m.Y)
+		}
+		if g.State == game.PLAYING {
+			return g
+		}
+	}
+}
+
+func TestMinimaxMatchesPlainMinimax(t *testing.T) {
+	tests := []struct {
+		name                 string
+		width, height, toWin int
+		minTokens            int // Tokens on the board before the search
+		positions            int
+	}{
+		{name: "3x3/3", width: 3, height: 3, toWin: 3, positions: 300},
+		{name: "4x4/3", width: 4, height: 4, toWin: 3, minTokens: 4, positions: 150},
+		{name: "4x4/4", width: 4, height: 4, toWin: 4, minTokens: 4, positions: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions := tt.positions
+			if testing.Short() {
+				positions /= 10
+			}
+			rng := rand.New(rand.NewSource(1))
+			ai := exactMinimax(NewTranspositionTable(testTableBytes))
+
+			for i := 0; i < positions; i++ {
+				g := randomPosition(rng, tt.width, tt.height, tt.toWin, tt.minTokens)
+				me := g.Current
+				opp := me.Opponent(g.Players)
+
+				// Plain Minimax: the first of the best moves in UniqueMoves order.
+				memo := map[memoKey]int{}
+				board := g.Board.Clone()
+				wantScore := initialLowerBound
+				var wantMove game.Move
+				for _, m := range board.UniqueMoves() {
+					if v := plainMove(board, me, me, opp, m, memo); v > wantScore {
+						wantScore, wantMove = v, m
+					}
+				}
+
+				got, info, err := ai.Search(context.Background(), g.Board, me, g.Players, Limits{})
+				if err != nil {
+					t.Fatalf("%s: %v", g.Encode(), err)
+				}
+				if got != wantMove || info.Score != wantScore {
+					t.Errorf("%s: got %v (score %d), want %v (score %d)",
+						g.Encode(), got, info.Score, wantMove, wantScore)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkMinimax solves the position of notation with a fresh table on
+// every iteration and reports the nodes visited.
+func benchmarkMinimax(b *testing.B, notation string) {
+	g := game.NewGame()
+	if err := g.Decode(notation); err != nil {
+		b.Fatal(err)
+	}
+
+	nodes := 0
+	for i := 0; i < b.N; i++ {
+		ai := exactMinimax(NewTranspositionTable(testTableBytes))
+		_, info, err := ai.Search(context.Background(), g.Board, g.Current, g.Players, Limits{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		nodes += info.Nodes
+	}
+	b.ReportMetric(float64(nodes)/float64(b.N), "nodes/op")
+}
+
+func BenchmarkMinimax3x3(b *testing.B) {
+	benchmarkMinimax(b, "3x3/3 3/3/3 a 2 -")
+}
+
+func BenchmarkMinimax4x4(b *testing.B) {
+	benchmarkMinimax(b, "4x4/4 a3/1b2/2a1/3b a 2 -")
+}
+
+// BenchmarkPlainMinimax3x3 is the reference for BenchmarkMinimax3x3: the
+// full Minimax tree of the empty board, without pruning nor table.
+func BenchmarkPlainMinimax3x3(b *testing.B) {
+	g := game.NewGame()
+	me, opp := g.Players[0], g.Players[1]
+	for i := 0; i < b.N; i++ {
+		plainMinimax(g.Board, me, me, opp, nil)
+	}
+}
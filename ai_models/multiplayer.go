@@ -0,0 +1,466 @@
+/**
+ ******************************************************************************
+ * @file            : multiplayer.go
+ * @brief           : GoTicTacToe - Multi-player search AI (max^n / paranoid)
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains a search AI for any number of players, following the
+ * turn order of Game.NextPlayer. Two classic generalisations of Minimax are
+ * available:
+ *   - max^n: every player maximizes their own score; positions are scored
+ *     with one value per player,
+ *   - paranoid: every other player is assumed to play against the AI, which
+ *     reduces the game to two sides and allows alpha-beta pruning.
+ *
+ * Like MinimaxAI, the search deepens one ply at a time within its budget and
+ * scores positions at the depth limit with the window heuristic; only cells
+ * next to existing tokens are considered.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// MultiplayerMode selects how MultiplayerAI models the other players.
+type MultiplayerMode int
+
+const (
+	// MODE_PARANOID assumes that all other players cooperate against the AI.
+	MODE_PARANOID MultiplayerMode = iota
+
+	// MODE_MAXN assumes that every player maximizes their own score.
+	MODE_MAXN
+)
+
+// Multi-player move ordering priorities.
+const (
+	mpOrderWin       = 1 << 20 // Move completing a line for the mover
+	mpOrderBlockNext = 1 << 19 // Move blocking the next player's line
+	mpOrderBlock     = 1 << 18 // Move blocking another player's line
+)
+
+// MultiplayerAI is an AI player searching games with any number of players.
+//
+// MinimaxAI delegates to it (in paranoid mode) when there are not exactly two
+// players.
+type MultiplayerAI struct {
+	Mode MultiplayerMode
+
+	// Budget is the thinking time per move: 0 uses DefaultMoveBudget, a
+	// negative value disables the time limit.
+	Budget time.Duration
+
+	// MaxNodes stops the search after visiting this many positions
+	// (0 = no node limit).
+	MaxNodes int
+}
+
+// NextMove returns the best move (x, y) of the last completed depth.
+func (ai MultiplayerAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	return ai.NextMoveWithBudget(board, me, players, 0)
+}
+
+// NextMoveWithBudget behaves like NextMove but stops deepening once budget
+// has elapsed (0 uses the AI's Budget, a negative budget disables the time
+// limit).
+func (ai MultiplayerAI) NextMoveWithBudget(board *game.Board, me *game.Player, players []*game.Player, budget time.Duration) (int, int) {
+	m, _, err := ai.Search(context.Background(), board, me, players, Limits{Budget: budget})
+	if err != nil {
+		return noMoveX, noMoveY
+	}
+	return m.X, m.Y
+}
+
+// Search implements Engine. Info.Score is the AI player's score of the move.
+func (ai MultiplayerAI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) (game.Move, Info, error) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, err
+	}
+
+	s := &mpSearch{
+		board:    board.Clone(),
+		players:  players,
+		me:       playerIndex(players, me),
+		mode:     ai.Mode,
+		empty:    board.EmptyCount(),
+		ctx:      ctx,
+		maxNodes: limits.MaxNodes,
+	}
+	if s.maxNodes == 0 {
+		s.maxNodes = ai.MaxNodes
+	}
+
+	budget := limits.Budget
+	if budget == 0 {
+		budget = ai.Budget
+	}
+	if budget == 0 {
+		budget = DefaultMoveBudget
+	}
+	if budget > 0 {
+		s.deadline = start.Add(budget)
+	}
+	if d, ok := ctx.Deadline(); ok && (s.deadline.IsZero() || d.Before(s.deadline)) {
+		s.deadline = d
+	}
+
+	maxDepth := s.empty
+	if limits.MaxDepth > 0 {
+		maxDepth = min(maxDepth, limits.MaxDepth)
+	}
+
+	candidates := s.rootMoves(board)
+	if len(candidates) == 0 {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, ErrNoMove
+	}
+
+	// Until a depth completes, play the most promising move.
+	info := Info{PV: candidates[:1]}
+	bestMove := candidates[0]
+	for depth := 1; depth <= maxDepth; depth++ {
+		mv, score, ok := s.searchRoot(candidates, depth)
+		if !ok {
+			break
+		}
+		bestMove = mv
+		info.Score, info.Depth, info.PV = score, depth, []game.Move{mv}
+	}
+	info.Nodes = s.nodes
+	info.Time = time.Since(start)
+
+	if err := ctx.Err(); errors.Is(err, context.Canceled) {
+		return bestMove, info, err
+	}
+	return bestMove, info, nil
+}
+
+// mpSearch holds the state of a single multi-player search.
+//
+// Players are referred to by their index in players, which is also the turn
+// order. The board is a private copy, modified in place with Play and Undo.
+type mpSearch struct {
+	board   *game.Board
+	players []*game.Player
+	me      int
+	mode    MultiplayerMode
+	empty   int // Empty cells left on board
+
+	ctx      context.Context
+	deadline time.Time // Time to stop searching (zero = no limit)
+	maxNodes int       // Nodes to stop after (0 = no limit)
+	nodes    int       // Nodes visited so far
+	aborted  bool      // Whether the budget ran out or ctx is done
+}
+
+// next returns the index of the player after turn.
+func (s *mpSearch) next(turn int) int {
+	return (turn + 1) % len(s.players)
+}
+
+// visit counts a node and checks the budget every nodeCheckInterval nodes.
+func (s *mpSearch) visit() {
+	s.nodes++
+	if s.maxNodes > 0 && s.nodes >= s.maxNodes {
+		s.aborted = true
+	}
+	if s.nodes%nodeCheckInterval != 0 {
+		return
+	}
+	if s.ctx.Err() != nil || (!s.deadline.IsZero() && time.Now().After(s.deadline)) {
+		s.aborted = true
+	}
+}
+
+// rootMoves returns the AI's candidate moves, keeping one move per class of
+// symmetric moves, best-first.
+func (s *mpSearch) rootMoves(board *game.Board) []game.Move {
+	unique := map[game.Move]bool{}
+	for _, m := range board.UniqueMoves() {
+		unique[m] = true
+	}
+
+	var moves []game.Move
+	for _, m := range s.moves(s.me) {
+		if unique[m] {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+// searchRoot searches every candidate depth plies deep and returns the best
+// one with the AI's score. It returns false if the budget ran out.
+func (s *mpSearch) searchRoot(candidates []game.Move, depth int) (game.Move, int, bool) {
+	bestScore := initialLowerBound
+	bestMove := candidates[0]
+
+	for _, m := range candidates {
+		var score int
+		if s.mode == MODE_MAXN {
+			score = s.playMaxN(s.me, m, depth)[s.me]
+		} else {
+			score = s.playParanoid(s.me, m, depth, bestScore, initialUpperBound)
+		}
+		if s.aborted {
+			return bestMove, bestScore, false
+		}
+		if score > bestScore {
+			bestScore, bestMove = score, m
+		}
+	}
+	return bestMove, bestScore, true
+}
+
+// playParanoid returns the AI's score after the player at index turn plays
+// m, searching depth plies (including m) within the window (alpha, beta).
+func (s *mpSearch) playParanoid(turn int, m game.Move, depth, alpha, beta int) int {
+	if s.board.WinsWith(s.players[turn], m.X, m.Y) {
+		if turn == s.me {
+			return scoreWin
+		}
+		return scoreLoss
+	}
+	if s.empty == 1 {
+		return scoreDraw
+	}
+
+	s.board.Play(s.players[turn], m.X, m.Y)
+	s.empty--
+	var score int
+	if depth <= 1 {
+		s.visit()
+		scores, winner := s.evaluate(s.next(turn))
+		score = s.paranoidScore(scores, winner)
+	} else {
+		score = s.paranoid(s.next(turn), depth-1, alpha, beta)
+	}
+	s.empty++
+	s.board.Undo(m.X, m.Y)
+	return score
+}
+
+// paranoid returns the AI's score of the current position with the player at
+// index turn to move: the AI maximizes, every other player minimizes.
+func (s *mpSearch) paranoid(turn, depth, alpha, beta int) int {
+	s.visit()
+	if s.aborted {
+		return scoreDraw
+	}
+
+	maximizing := turn == s.me
+	best := initialUpperBound
+	if maximizing {
+		best = initialLowerBound
+	}
+
+	for _, m := range s.moves(turn) {
+		score := s.playParanoid(turn, m, depth, alpha, beta)
+		if s.aborted {
+			return scoreDraw
+		}
+		if maximizing {
+			best = max(best, score)
+			alpha = max(alpha, best)
+		} else {
+			best = min(best, score)
+			beta = min(beta, best)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+// paranoidScore reduces per-player scores to the AI's side against all the
+// others.
+func (s *mpSearch) paranoidScore(scores []int, winner int) int {
+	switch {
+	case winner == s.me:
+		return scoreWin
+	case winner >= 0:
+		return scoreLoss
+	}
+
+	score := 0
+	for i, v := range scores {
+		if i == s.me {
+			score += v
+		} else {
+			score -= v
+		}
+	}
+	return min(max(score, scoreLoss+1), scoreWin-1)
+}
+
+// playMaxN returns the score of every player after the player at index turn
+// plays m, searching depth plies (including m).
+func (s *mpSearch) playMaxN(turn int, m game.Move, depth int) []int {
+	if s.board.WinsWith(s.players[turn], m.X, m.Y) {
+		return s.terminalScores(turn)
+	}
+	if s.empty == 1 {
+		return s.terminalScores(-1)
+	}
+
+	s.board.Play(s.players[turn], m.X, m.Y)
+	s.empty--
+	var scores []int
+	if depth <= 1 {
+		s.visit()
+		scores = s.maxNScores(s.next(turn))
+	} else {
+		scores = s.maxN(s.next(turn), depth-1)
+	}
+	s.empty++
+	s.board.Undo(m.X, m.Y)
+	return scores
+}
+
+// maxN returns the score of every player for the current position with the
+// player at index turn to move, who picks the move maximizing their own
+// score.
+func (s *mpSearch) maxN(turn, depth int) []int {
+	s.visit()
+	if s.aborted {
+		return s.terminalScores(-1)
+	}
+
+	var best []int
+	for _, m := range s.moves(turn) {
+		scores := s.playMaxN(turn, m, depth)
+		if s.aborted {
+			return s.terminalScores(-1)
+		}
+		if best == nil || scores[turn] > best[turn] {
+			best = scores
+		}
+		// Nothing beats a win (shallow pruning).
+		if best[turn] == scoreWin {
+			break
+		}
+	}
+	return best
+}
+
+// maxNScores returns the heuristic score of every player: their own windows
+// against those of their strongest rival.
+func (s *mpSearch) maxNScores(toMove int) []int {
+	values, winner := s.evaluate(toMove)
+	if winner >= 0 {
+		return s.terminalScores(winner)
+	}
+
+	scores := make([]int, len(values))
+	for i, v := range values {
+		rival := 0
+		for j, w := range values {
+			if j != i {
+				rival = max(rival, w)
+			}
+		}
+		scores[i] = min(max(v-rival, scoreLoss+1), scoreWin-1)
+	}
+	return scores
+}
+
+// terminalScores returns the scores of a finished game won by winner (-1 for
+// a draw).
+func (s *mpSearch) terminalScores(winner int) []int {
+	scores := make([]int, len(s.players))
+	if winner < 0 {
+		return scores
+	}
+	for i := range scores {
+		scores[i] = scoreLoss
+	}
+	scores[winner] = scoreWin
+	return scores
+}
+
+// evaluate returns the window value of every player (see evaluate.go), and
+// the index of the player to move if they complete a line on their next
+// move (-1 otherwise).
+func (s *mpSearch) evaluate(toMove int) ([]int, int) {
+	b := s.board
+	length := b.WinLength()
+	values := make([]int, len(s.players))
+
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			for _, dir := range evalDirections {
+				endX, endY := x+dir.DX*(length-1), y+dir.DY*(length-1)
+				if endX < 0 || endY < 0 || endX >= b.Width || endY >= b.Height {
+					continue
+				}
+
+				owner, stones := windowOwner(b, x, y, dir, length)
+				if owner == nil || stones == 0 {
+					continue
+				}
+				i := playerIndex(s.players, owner)
+				if i == toMove && stones == length-1 {
+					return values, toMove
+				}
+
+				value := windowValue(stones)
+				values[i] += value + value*openEndBonus*openEnds(b, x, y, dir, length)
+			}
+		}
+	}
+	return values, -1
+}
+
+// moves returns the moves considered for the player at index turn, best
+// first: cells next to a token (any cell on an empty board), sorted by
+// wins, blocks of the next player, blocks of the others, then centrality.
+func (s *mpSearch) moves(turn int) []game.Move {
+	all := s.board.AvailableMoves()
+	var moves []game.Move
+	for _, m := range all {
+		if s.board.HasNeighbour(m.X, m.Y) {
+			moves = append(moves, m)
+		}
+	}
+	if len(moves) == 0 {
+		moves = all
+	}
+
+	keys := make(map[game.Move]int, len(moves))
+	for _, m := range moves {
+		key := -(abs(2*m.X-(s.board.Width-1)) + abs(2*m.Y-(s.board.Height-1)))
+		if s.board.WinsWith(s.players[turn], m.X, m.Y) {
+			key += mpOrderWin
+		}
+		for i, p := range s.players {
+			if i == turn || !s.board.WinsWith(p, m.X, m.Y) {
+				continue
+			}
+			if i == s.next(turn) {
+				key += mpOrderBlockNext
+			} else {
+				key += mpOrderBlock
+			}
+			break
+		}
+		keys[m] = key
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool {
+		return keys[moves[i]] > keys[moves[j]]
+	})
+	return moves
+}
@@ -0,0 +1,108 @@
+/**
+ ******************************************************************************
+ * @file            : ordering.go
+ * @brief           : GoTicTacToe - Move ordering for the Minimax search
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * Alpha-beta pruning cuts the most when the best move is searched first.
+ * This file sorts the moves of a node by, in decreasing priority:
+ *   - the hash move: best move stored in the transposition table,
+ *   - threats: moves that win at once, then moves that block such a move,
+ *   - killer moves: moves that caused a cutoff at the same ply,
+ *   - history: moves that caused cutoffs anywhere, weighted by depth,
+ *   - centrality: moves closer to the center of the board.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"sort"
+)
+
+// Move ordering priorities. Each tier must outweigh everything below it.
+const (
+	orderHash   = 1 << 30 // Best move stored in the transposition table
+	orderWin    = 1 << 29 // Move completing a line for the mover
+	orderBlock  = 1 << 28 // Move completing a line for the other player
+	orderKiller = 1 << 24 // Killer move (first slot; later slots rank lower)
+
+	// orderHistoryMax caps history scores below the last killer slot.
+	orderHistoryMax = orderKiller>>killerSlots - 1
+
+	// killerSlots is the number of killer moves remembered per ply.
+	killerSlots = 2
+)
+
+// noKiller marks an empty killer slot.
+var noKiller = game.Move{X: -1, Y: -1}
+
+// order returns the indices of moves sorted from the most to the least
+// promising for player p at the given ply, hashMove first. Ties keep the
+// order of moves.
+func (s *search) order(moves []game.Move, p *game.Player, ply int, hashMove game.Move) []int {
+	other := s.me
+	if p == s.me {
+		other = s.opp
+	}
+
+	keys := make([]int, len(moves))
+	indices := make([]int, len(moves))
+	for i, mv := range moves {
+		indices[i] = i
+
+		key := min(s.history[mv.X][mv.Y], orderHistoryMax) - s.distanceToCenter(mv)
+		switch {
+		case mv == hashMove:
+			key += orderHash
+		case s.board.WinsWith(p, mv.X, mv.Y):
+			key += orderWin
+		case s.board.WinsWith(other, mv.X, mv.Y):
+			key += orderBlock
+		}
+		for slot, killer := range s.killers[ply] {
+			if killer == mv {
+				key += orderKiller >> slot
+			}
+		}
+		keys[i] = key
+	}
+
+	sort.SliceStable(indices, func(a, b int) bool {
+		return keys[indices[a]] > keys[indices[b]]
+	})
+	return indices
+}
+
+// cutoff records that mv closed the search window at the given ply, with
+// depth plies left to search.
+func (s *search) cutoff(mv game.Move, ply, depth int) {
+	killers := &s.killers[ply]
+	if killers[0] != mv {
+		copy(killers[1:], killers[:killerSlots-1])
+		killers[0] = mv
+	}
+
+	// Cutoffs near the root prune larger subtrees: weight them more.
+	s.history[mv.X][mv.Y] += depth * depth
+}
+
+// distanceToCenter returns the Manhattan distance from mv to the center of
+// the board, doubled so that it stays an integer on even-sized boards.
+func (s *search) distanceToCenter(mv game.Move) int {
+	return abs(2*mv.X-(s.board.Width-1)) + abs(2*mv.Y-(s.board.Height-1))
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
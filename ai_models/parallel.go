@@ -0,0 +1,99 @@
+/**
+ ******************************************************************************
+ * @file            : parallel.go
+ * @brief           : GoTicTacToe - Parallel search (Lazy SMP)
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains the parallel search of MinimaxAI, following the Lazy SMP
+ * scheme: several workers run the same iterative deepening search on their
+ * own copy of the board, and only share the transposition table.
+ *
+ * Workers do not split the work explicitly. They get ahead of each other
+ * (every other helper starts one ply deeper), so each one finds many
+ * positions already searched by the others in the table and spends its
+ * time further down the tree. The move of the deepest completed search is
+ * played, the main worker's on ties.
+ *
+ * The main worker runs on the calling goroutine and decides when the search
+ * ends: helpers are stopped as soon as it is done.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultWorkers returns the number of workers of a search using the whole
+// machine: one per CPU usable by the program. MinimaxAI only searches in
+// parallel when its Workers field asks for it.
+func DefaultWorkers() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// sharedSearch is the state shared by the workers of a parallel search.
+type sharedSearch struct {
+	stop     atomic.Bool  // Set when the search must end
+	nodes    atomic.Int64 // Nodes visited by all workers, counted by batches
+	maxNodes int64        // Nodes to stop after, for all workers (0 = no limit)
+}
+
+// poll adds a batch of nodes visited by a worker and reports whether the
+// search must end.
+func (sh *sharedSearch) poll(nodes int) bool {
+	total := sh.nodes.Add(int64(nodes))
+	if sh.maxNodes > 0 && total >= sh.maxNodes {
+		sh.stop.Store(true)
+	}
+	return sh.stop.Load()
+}
+
+// deepenParallel runs deepen on s and on workers-1 helper searches sharing
+// its table, and returns the result of the deepest completed search.
+//
+// The nodes of the helpers are added to s.nodes. The node limit of s
+// applies to all workers together.
+func (s *search) deepenParallel(candidates []game.Move, maxDepth int, first game.Move, players []*game.Player, workers int) rootResult {
+	shared := &sharedSearch{maxNodes: int64(s.maxNodes)}
+	s.shared, s.maxNodes = shared, 0
+
+	results := make([]rootResult, workers)
+	helpers := make([]*search, workers-1)
+	var wg sync.WaitGroup
+	for i := range helpers {
+		h := newSearch(s.board, s.me, players, s.table)
+		h.ctx, h.deadline, h.shared = s.ctx, s.deadline, shared
+		helpers[i] = h
+
+		worker := i + 1
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[worker] = h.deepen(candidates, 1+worker%2, maxDepth, first)
+		}()
+	}
+
+	results[0] = s.deepen(candidates, 1, maxDepth, first)
+	shared.stop.Store(true)
+	wg.Wait()
+
+	best := results[0]
+	for _, r := range results[1:] {
+		if r.depth > best.depth {
+			best = r
+		}
+	}
+	for _, h := range helpers {
+		s.nodes += h.nodes
+	}
+	return best
+}
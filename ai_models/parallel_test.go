@@ -0,0 +1,140 @@
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// benchDepth is the depth of the searches of the worker benchmarks.
+const benchDepth = 4
+
+// middleGame returns a game of plies moves played by the rule-based AI, the
+// same for a given seed. Games that end early are replayed with another seed.
+func middleGame(width, height, toWin, plies int, seed int64) *game.Game {
+	for {
+		g := game.NewGameWithConfig(width, height, toWin, nil)
+		for i := 0; i < plies && g.State == game.PLAYING; i++ {
+			x, y := RuleAI{Seed: seed + int64(i)}.NextMove(g.Board, g.Current, g.Players)
+			g.PlayMove(x, y)
+		}
+		if g.State == game.PLAYING {
+			return g
+		}
+		seed += int64(plies)
+	}
+}
+
+func TestSearchSequential(t *testing.T) {
+	tests := []struct {
+		notation string
+		move     string
+		score    int
+	}{
+		// Every first move draws: the first one in UniqueMoves order is played.
+		{notation: "3x3/3 3/3/3 a 2 -", move: "a1", score: scoreDraw},
+		{notation: "3x3/3 aa1/bb1/3 a 2 -", move: "c1", score: scoreWin},
+		{notation: "3x3/3 aa1/1b1/3 b 2 -", move: "c1", score: scoreDraw},
+		// Answering the centre on an edge loses.
+		{notation: "3x3/3 3/1a1/1b1 a 2 -", move: "a1", score: scoreWin},
+		{notation: "4x4/3 4/4/4/4 a 2 -", move: "a1", score: scoreWin},
+		{notation: "4x4/4 a3/1b2/2a1/3b a 2 -", move: "a2", score: scoreDraw},
+	}
+
+	for _, tt := range tests {
+		g := game.NewGame()
+		if err := g.Decode(tt.notation); err != nil {
+			t.Fatal(err)
+		}
+		want := parseMoves(t, tt.move)[0]
+
+		// Workers 0 and 1 both search on the calling goroutine.
+		for _, workers := range []int{0, 1} {
+			ai := exactMinimax(NewTranspositionTable(testTableBytes))
+			ai.Workers = workers
+			m, info, err := ai.Search(context.Background(), g.Board, g.Current, g.Players, Limits{})
+			if err != nil {
+				t.Fatalf("%s: %v", tt.notation, err)
+			}
+			if m != want || info.Score != tt.score {
+				t.Errorf("%s, %d workers: %v (score %d), want %v (score %d)",
+					tt.notation, workers, m, info.Score, want, tt.score)
+			}
+		}
+	}
+}
+
+func TestSearchSequentialIsReproducible(t *testing.T) {
+	for seed := int64(1); seed <= 4; seed++ {
+		g := middleGame(8, 8, 5, 8, seed)
+
+		var first Info
+		var firstMove game.Move
+		for run := 0; run < 2; run++ {
+			ai := MinimaxAI{Table: NewTranspositionTable(testTableBytes), Budget: -1, ThreatDepth: -1}
+			m, info, err := ai.Search(context.Background(), g.Board, g.Current, g.Players, Limits{MaxDepth: benchDepth})
+			if err != nil {
+				t.Fatalf("%s: %v", g.Encode(), err)
+			}
+			if run == 0 {
+				first, firstMove = info, m
+				continue
+			}
+			if m != firstMove || info.Score != first.Score || info.Nodes != first.Nodes {
+				t.Errorf("%s: %v (score %d, %d nodes), then %v (score %d, %d nodes)", g.Encode(),
+					firstMove, first.Score, first.Nodes, m, info.Score, info.Nodes)
+			}
+		}
+	}
+}
+
+func TestSearchWorkersAgreeOnSolvedPositions(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 30; i++ {
+		g := randomPosition(rng, 3, 3, 3, 0)
+		me := g.Current
+		want := plainMinimax(g.Board.Clone(), me, me, me.Opponent(g.Players), map[memoKey]int{})
+
+		for _, workers := range []int{2, 4} {
+			ai := exactMinimax(NewTranspositionTable(testTableBytes))
+			ai.Workers = workers
+			_, info, err := ai.Search(context.Background(), g.Board, me, g.Players, Limits{})
+			if err != nil {
+				t.Fatalf("%s: %v", g.Encode(), err)
+			}
+			if info.Score != want {
+				t.Errorf("%s: %d workers score %d, want %d", g.Encode(), workers, info.Score, want)
+			}
+		}
+	}
+}
+
+// benchmarkSearchWorkers searches a few middle-game positions to a fixed
+// depth with the given number of workers, each on a fresh table. Comparing
+// the time per operation across worker counts gives the parallel speedup.
+func benchmarkSearchWorkers(b *testing.B, workers int) {
+	var positions []*game.Game
+	for seed := int64(1); seed <= 4; seed++ {
+		positions = append(positions, middleGame(8, 8, 5, 8, seed))
+	}
+
+	nodes := 0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, g := range positions {
+			ai := MinimaxAI{Table: NewTranspositionTable(testTableBytes), Budget: -1, ThreatDepth: -1, Workers: workers}
+			_, info, err := ai.Search(context.Background(), g.Board, g.Current, g.Players, Limits{MaxDepth: benchDepth})
+			if err != nil {
+				b.Fatal(err)
+			}
+			nodes += info.Nodes
+		}
+	}
+	b.ReportMetric(float64(nodes)/float64(b.N), "nodes/op")
+}
+
+func BenchmarkSearchWorkers1(b *testing.B) { benchmarkSearchWorkers(b, 1) }
+func BenchmarkSearchWorkers2(b *testing.B) { benchmarkSearchWorkers(b, 2) }
+func BenchmarkSearchWorkers4(b *testing.B) { benchmarkSearchWorkers(b, 4) }
+func BenchmarkSearchWorkers8(b *testing.B) { benchmarkSearchWorkers(b, 8) }
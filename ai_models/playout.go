@@ -0,0 +1,88 @@
+/**
+ ******************************************************************************
+ * @file            : playout.go
+ * @brief           : GoTicTacToe - Playout policies for the MCTS AI
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains the policies MCTSAI uses to finish games quickly
+ * during its playouts: uniformly random moves, or a cheap heuristic that
+ * takes wins, blocks the next player's wins and plays near existing tokens.
+ * Heuristic playouts are slower but much closer to real games, which makes
+ * the statistics more reliable for the same thinking time.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"math/rand"
+)
+
+// nearbyPlayoutProbability is the chance that HeuristicPlayout plays next to
+// an existing token rather than anywhere.
+const nearbyPlayoutProbability = 0.8
+
+// PlayoutPolicy picks the moves of MCTS playouts.
+type PlayoutPolicy interface {
+	// Move returns the move of toMove on board, or false if the board is
+	// full. It must not modify board.
+	Move(board *game.Board, toMove *game.Player, players []*game.Player, rng *rand.Rand) (game.Move, bool)
+}
+
+// RandomPlayout plays uniformly random moves.
+type RandomPlayout struct{}
+
+// Move returns a random empty cell.
+func (RandomPlayout) Move(board *game.Board, _ *game.Player, _ []*game.Player, rng *rand.Rand) (game.Move, bool) {
+	moves := board.AvailableMoves()
+	if len(moves) == 0 {
+		return game.Move{}, false
+	}
+	return moves[rng.Intn(len(moves))], true
+}
+
+// HeuristicPlayout completes a line when it can, otherwise blocks a line the
+// next player would complete, and otherwise plays a random move, preferably
+// next to an existing token.
+type HeuristicPlayout struct{}
+
+// Move returns the heuristic move of toMove.
+func (HeuristicPlayout) Move(board *game.Board, toMove *game.Player, players []*game.Player, rng *rand.Rand) (game.Move, bool) {
+	moves := board.AvailableMoves()
+	if len(moves) == 0 {
+		return game.Move{}, false
+	}
+
+	next := players[(playerIndex(players, toMove)+1)%len(players)]
+	var nearby []game.Move
+	block, canBlock := game.Move{}, false
+
+	for _, m := range moves {
+		if !board.HasNeighbour(m.X, m.Y) {
+			continue
+		}
+		// Winning and blocking cells are always next to a token.
+		if board.WinsWith(toMove, m.X, m.Y) {
+			return m, true
+		}
+		if !canBlock && next != toMove && board.WinsWith(next, m.X, m.Y) {
+			block, canBlock = m, true
+		}
+		nearby = append(nearby, m)
+	}
+
+	switch {
+	case canBlock:
+		return block, true
+	case len(nearby) > 0 && rng.Float64() < nearbyPlayoutProbability:
+		return nearby[rng.Intn(len(nearby))], true
+	default:
+		return moves[rng.Intn(len(moves))], true
+	}
+}
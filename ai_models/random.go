@@ -24,6 +24,7 @@ package ai_models
 
 import (
 	"GoTicTacToe/game"
+	"context"
 	"math/rand"
 )
 
@@ -53,3 +54,17 @@ func (RandomAI) NextMove(board *game.Board, _ *game.Player, _ []*game.Player) (i
 	m := moves[rand.Intn(len(moves))]
 	return m.X, m.Y
 }
+
+// Search implements Engine. Limits are ignored: picking a move is instant.
+func (ai RandomAI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, _ Limits) (game.Move, Info, error) {
+	if err := ctx.Err(); err != nil {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, err
+	}
+
+	x, y := ai.NextMove(board, me, players)
+	m := game.Move{X: x, Y: y}
+	if x == noMoveX && y == noMoveY {
+		return m, Info{}, ErrNoMove
+	}
+	return m, Info{PV: []game.Move{m}}, nil
+}
@@ -0,0 +1,397 @@
+/**
+ ******************************************************************************
+ * @file            : rules.go
+ * @brief           : GoTicTacToe - Rule-based AI (Newell & Simon strategy)
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains RuleAI, a "medium" AI player following the Newell &
+ * Simon Tic-Tac-Toe strategy, generalised to k-in-a-row. The first rule that
+ * applies gives the move:
+ *   1. win: complete a line,
+ *   2. block: stop an opponent from completing a line,
+ *   3. fork: create two winning cells at once,
+ *   4. block fork: stop an opponent's fork, if possible by forcing them to
+ *      defend somewhere harmless,
+ *   5. center, 6. opposite corner, 7. corner, 8. side.
+ *
+ * The corner and side rules only make sense when lines span the board
+ * (e.g. 3x3 with 3 to win). On larger boards they are replaced by the cell
+ * lying in the most valuable open windows, which also ranks the center first,
+ * corners next and sides last on a 3x3 board.
+ *
+ * Nothing is searched, so moves are instant on any board. Ties are broken by
+ * the same window value, then at random.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Rule identifies the rule of RuleAI that chose a move.
+type Rule int
+
+const (
+	RULE_NONE            Rule = iota // No move: the board is full
+	RULE_WIN                         // Complete a line
+	RULE_BLOCK                       // Block an opponent's winning cell
+	RULE_FORK                        // Create two winning cells at once
+	RULE_BLOCK_FORK                  // Block an opponent's fork
+	RULE_CENTER                      // Take the center
+	RULE_OPPOSITE_CORNER             // Take the corner opposite an opponent's
+	RULE_CORNER                      // Take a corner
+	RULE_SIDE                        // Take a side cell
+	RULE_POSITION                    // Take the cell in the most valuable open windows
+)
+
+// ruleNames are the descriptions of the rules, indexed by Rule.
+var ruleNames = [...]string{
+	RULE_NONE:            "no move",
+	RULE_WIN:             "win",
+	RULE_BLOCK:           "block",
+	RULE_FORK:            "fork",
+	RULE_BLOCK_FORK:      "block fork",
+	RULE_CENTER:          "center",
+	RULE_OPPOSITE_CORNER: "opposite corner",
+	RULE_CORNER:          "corner",
+	RULE_SIDE:            "side",
+	RULE_POSITION:        "best position",
+}
+
+// String returns the description of the rule (e.g. "block fork").
+func (r Rule) String() string {
+	if r < 0 || int(r) >= len(ruleNames) {
+		return "unknown rule"
+	}
+	return ruleNames[r]
+}
+
+// RuleAI is an AI player following fixed rules (see Explain).
+//
+// It never looks ahead more than a forced reply: it beats careless players
+// and never misses a win or a block, but it can be outplayed by deeper
+// threats. For a given Seed, its moves only depend on the position.
+type RuleAI struct {
+	Seed int64 // Random seed for ties (0 = seeded from the clock)
+}
+
+// NextMove returns the move (x, y) chosen by the first applicable rule.
+func (ai RuleAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	m, _ := ai.Explain(board, me, players)
+	return m.X, m.Y
+}
+
+// Search implements Engine. Limits are ignored: picking a move is instant.
+func (ai RuleAI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, _ Limits) (game.Move, Info, error) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, err
+	}
+
+	m, rule := ai.Explain(board, me, players)
+	if rule == RULE_NONE {
+		return m, Info{}, ErrNoMove
+	}
+	return m, Info{PV: []game.Move{m}, Time: time.Since(start)}, nil
+}
+
+// Explain returns the move of me and the rule that chose it. Rules are tried
+// in the order of the Rule constants; opponents are considered in turn order,
+// the next player first. The rules look ahead on a copy of board, so board
+// may be shared with other readers.
+//
+// If the board is full, it returns (noMoveX, noMoveY) and RULE_NONE.
+func (ai RuleAI) Explain(board *game.Board, me *game.Player, players []*game.Player) (game.Move, Rule) {
+	seed := ai.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := &ruleSearch{
+		board:   board.Clone(),
+		me:      me,
+		players: players,
+		length:  board.WinLength(),
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+
+	if len(board.AvailableMoves()) == 0 {
+		return game.Move{X: noMoveX, Y: noMoveY}, RULE_NONE
+	}
+	if wins := r.wins(me); len(wins) > 0 {
+		return r.best(wins), RULE_WIN
+	}
+	opponents := r.opponents()
+	for _, opp := range opponents {
+		if wins := r.wins(opp); len(wins) > 0 {
+			return r.best(wins), RULE_BLOCK
+		}
+	}
+	if forks := r.forks(me); len(forks) > 0 {
+		return r.best(forks), RULE_FORK
+	}
+	for _, opp := range opponents {
+		if forks := r.forks(opp); len(forks) > 0 {
+			return r.blockFork(opp, forks), RULE_BLOCK_FORK
+		}
+	}
+
+	if center := r.empty(r.centers()); len(center) > 0 {
+		return r.best(center), RULE_CENTER
+	}
+	if r.length == min(board.Width, board.Height) {
+		if opposite := r.oppositeCorners(); len(opposite) > 0 {
+			return r.best(opposite), RULE_OPPOSITE_CORNER
+		}
+		if corners := r.empty(r.corners()); len(corners) > 0 {
+			return r.best(corners), RULE_CORNER
+		}
+		if sides := r.empty(r.sides()); len(sides) > 0 {
+			return r.best(sides), RULE_SIDE
+		}
+	}
+	return r.best(board.AvailableMoves()), RULE_POSITION
+}
+
+// ruleSearch holds the state of one RuleAI decision.
+type ruleSearch struct {
+	board   *game.Board
+	me      *game.Player
+	players []*game.Player
+	length  int // Cells in a winning line
+	rng     *rand.Rand
+}
+
+// opponents returns the other players in turn order, starting with the
+// player after me.
+func (r *ruleSearch) opponents() []*game.Player {
+	var opponents []*game.Player
+	p := r.me
+	for range r.players {
+		p = p.Next(r.players)
+		if p != r.me {
+			opponents = append(opponents, p)
+		}
+	}
+	return opponents
+}
+
+// wins returns the empty cells where p completes a line at once.
+func (r *ruleSearch) wins(p *game.Player) []game.Move {
+	var wins []game.Move
+	for _, m := range r.board.AvailableMoves() {
+		if r.board.WinsWith(p, m.X, m.Y) {
+			wins = append(wins, m)
+		}
+	}
+	return wins
+}
+
+// winsThrough returns the cells that would complete a line of p through c
+// if c held a token of p (c must be empty or hold a token of p).
+func (r *ruleSearch) winsThrough(p *game.Player, c game.Move) []game.Move {
+	var wins []game.Move
+	r.windowsThrough(c, func(x, y int, dir game.Direction) {
+		var win game.Move
+		empty := 0
+		for step := 0; step < r.length; step++ {
+			cell := game.Move{X: x + dir.DX*step, Y: y + dir.DY*step}
+			switch r.board.Cells[cell.X][cell.Y] {
+			case p:
+			case nil:
+				if cell != c {
+					win = cell
+					empty++
+				}
+			default:
+				return
+			}
+		}
+		if empty == 1 && !contains(wins, win) {
+			wins = append(wins, win)
+		}
+	})
+	return wins
+}
+
+// forks returns the empty cells where p would have two or more winning
+// cells, without winning at once.
+func (r *ruleSearch) forks(p *game.Player) []game.Move {
+	existing := r.wins(p)
+
+	var forks []game.Move
+	for _, c := range r.board.AvailableMoves() {
+		if contains(existing, c) {
+			continue
+		}
+		count := len(existing)
+		for _, w := range r.winsThrough(p, c) {
+			if !contains(existing, w) {
+				count++
+			}
+		}
+		if count >= 2 {
+			forks = append(forks, c)
+		}
+	}
+	return forks
+}
+
+// blockFork returns the move of me against the fork cells of opp.
+//
+// A single fork cell is simply taken. Against several, when opp plays next,
+// the best answer is a threat whose forced block does not give opp a fork
+// (taking one fork cell would leave the other). Failing that, a fork cell
+// is taken.
+func (r *ruleSearch) blockFork(opp *game.Player, forks []game.Move) game.Move {
+	if len(forks) == 1 || opp != r.me.Next(r.players) {
+		return r.best(forks)
+	}
+
+	var forcing []game.Move
+	for _, m := range r.board.AvailableMoves() {
+		threats := r.winsThrough(r.me, m)
+		if len(threats) != 1 {
+			continue
+		}
+		block := threats[0]
+
+		r.board.Play(r.me, m.X, m.Y)
+		if len(r.winsThrough(opp, block)) < 2 {
+			forcing = append(forcing, m)
+		}
+		r.board.Undo(m.X, m.Y)
+	}
+	if len(forcing) > 0 {
+		return r.best(forcing)
+	}
+	return r.best(forks)
+}
+
+// centers returns the central cells of the board (one to four cells,
+// depending on the parity of its dimensions).
+func (r *ruleSearch) centers() []game.Move {
+	var cells []game.Move
+	w, h := r.board.Width, r.board.Height
+	for _, x := range [...]int{(w - 1) / 2, w / 2} {
+		for _, y := range [...]int{(h - 1) / 2, h / 2} {
+			if c := (game.Move{X: x, Y: y}); !contains(cells, c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// corners returns the four corners of the board.
+func (r *ruleSearch) corners() []game.Move {
+	w, h := r.board.Width-1, r.board.Height-1
+	return []game.Move{{X: 0, Y: 0}, {X: w, Y: 0}, {X: 0, Y: h}, {X: w, Y: h}}
+}
+
+// oppositeCorners returns the empty corners opposite a corner held by an
+// opponent.
+func (r *ruleSearch) oppositeCorners() []game.Move {
+	var cells []game.Move
+	for _, c := range r.corners() {
+		owner := r.board.Cells[c.X][c.Y]
+		opposite := game.Move{X: r.board.Width - 1 - c.X, Y: r.board.Height - 1 - c.Y}
+		if owner != nil && owner != r.me && r.board.Cells[opposite.X][opposite.Y] == nil {
+			cells = append(cells, opposite)
+		}
+	}
+	return cells
+}
+
+// sides returns the cells on the edge of the board, corners excluded.
+func (r *ruleSearch) sides() []game.Move {
+	var cells []game.Move
+	w, h := r.board.Width, r.board.Height
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			edgeX, edgeY := x == 0 || x == w-1, y == 0 || y == h-1
+			if edgeX != edgeY {
+				cells = append(cells, game.Move{X: x, Y: y})
+			}
+		}
+	}
+	return cells
+}
+
+// empty returns the empty cells among cells.
+func (r *ruleSearch) empty(cells []game.Move) []game.Move {
+	var free []game.Move
+	for _, c := range cells {
+		if r.board.Cells[c.X][c.Y] == nil {
+			free = append(free, c)
+		}
+	}
+	return free
+}
+
+// best returns the cell of cells (not empty) with the highest value, ties
+// broken at random.
+func (r *ruleSearch) best(cells []game.Move) game.Move {
+	var best []game.Move
+	bestValue := -1
+	for _, c := range cells {
+		v := r.value(c)
+		if v > bestValue {
+			best, bestValue = best[:0], v
+		}
+		if v == bestValue {
+			best = append(best, c)
+		}
+	}
+	return best[r.rng.Intn(len(best))]
+}
+
+// value returns the sum of the values (see windowValue) of the windows
+// through c that a single player can still complete: taking c extends mine
+// and blocks the others'.
+func (r *ruleSearch) value(c game.Move) int {
+	value := 0
+	r.windowsThrough(c, func(x, y int, dir game.Direction) {
+		owner, stones := windowOwner(r.board, x, y, dir, r.length)
+		if owner != nil || r.windowEmpty(x, y, dir) {
+			value += windowValue(stones)
+		}
+	})
+	return value
+}
+
+// windowEmpty reports whether the window starting at (x, y) in direction dir
+// holds no token.
+func (r *ruleSearch) windowEmpty(x, y int, dir game.Direction) bool {
+	for step := 0; step < r.length; step++ {
+		if r.board.Cells[x+dir.DX*step][y+dir.DY*step] != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// windowsThrough calls visit with the start and direction of every window
+// of length cells containing c.
+func (r *ruleSearch) windowsThrough(c game.Move, visit func(x, y int, dir game.Direction)) {
+	for _, dir := range evalDirections {
+		for back := 0; back < r.length; back++ {
+			x, y := c.X-dir.DX*back, c.Y-dir.DY*back
+			endX, endY := x+dir.DX*(r.length-1), y+dir.DY*(r.length-1)
+			if x < 0 || y < 0 || x >= r.board.Width || y >= r.board.Height ||
+				endX < 0 || endY < 0 || endX >= r.board.Width || endY >= r.board.Height {
+				continue
+			}
+			visit(x, y, dir)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/**
+ ******************************************************************************
+ * @file            : skill.go
+ * @brief           : GoTicTacToe - AI of adjustable strength (skill levels)
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains SkillAI, an AI player whose strength is set by a level
+ * from 1 (beatable by children) to 10 (MinimaxAI, perfect on small boards).
+ *
+ * Below the top level, three knobs weaken the play:
+ *   - the search depth,
+ *   - the blunder probability: the chance of playing a random move,
+ *   - the noise: random amounts added to the move scores before picking
+ *     the best one, so that moves of similar value are mixed up.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Skill levels.
+const (
+	MinSkillLevel     = 1  // Weakest level
+	MaxSkillLevel     = 10 // Strongest level (MinimaxAI)
+	DefaultSkillLevel = 5  // Level of a SkillAI without Level
+)
+
+// skillProfile holds the settings of a skill level.
+type skillProfile struct {
+	depth   int     // Search depth in plies
+	blunder float64 // Probability of playing a random move
+	noise   float64 // Standard deviation of the noise added to move scores
+}
+
+// skillProfiles are the settings of the levels below MaxSkillLevel, from
+// MinSkillLevel up.
+//
+// Noise is in evaluation units (see evaluate.go): it mixes up quiet moves
+// but never makes a win or a loss look like something else.
+var skillProfiles = [MaxSkillLevel - MinSkillLevel]skillProfile{
+	{depth: 1, blunder: 0.50, noise: 400},
+	{depth: 1, blunder: 0.35, noise: 200},
+	{depth: 2, blunder: 0.25, noise: 120},
+	{depth: 2, blunder: 0.15, noise: 60},
+	{depth: 3, blunder: 0.10, noise: 30},
+	{depth: 3, blunder: 0.06, noise: 15},
+	{depth: 4, blunder: 0.03, noise: 8},
+	{depth: 5, blunder: 0.01, noise: 4},
+	{depth: 6, blunder: 0, noise: 2},
+}
+
+// SkillAI is an AI player of adjustable strength.
+//
+// At MaxSkillLevel it plays like MinimaxAI. Below, moves are searched
+// shallower, their scores are blurred by noise, and random moves are played
+// now and then (see skillProfiles). With other than two players, the search
+// is done by MultiplayerAI at the level's depth, without noise.
+type SkillAI struct {
+	Level int // Skill level (0 = DefaultSkillLevel), clamped to MinSkillLevel..MaxSkillLevel
+
+	// Budget is the thinking time per move: 0 uses DefaultMoveBudget, a
+	// negative value disables the time limit.
+	Budget time.Duration
+
+	Seed int64 // Random seed (0 = seeded from the clock)
+
+	// Workers is the number of search workers at MaxSkillLevel (see
+	// MinimaxAI.Workers). Lower levels always search sequentially.
+	Workers int
+}
+
+// EffectiveLevel returns the level actually played: Level, defaulted and
+// clamped to MinSkillLevel..MaxSkillLevel.
+func (ai SkillAI) EffectiveLevel() int {
+	if ai.Level == 0 {
+		return DefaultSkillLevel
+	}
+	return min(max(ai.Level, MinSkillLevel), MaxSkillLevel)
+}
+
+// String returns the name and level of the AI, as shown in game records.
+func (ai SkillAI) String() string {
+	return fmt.Sprintf("SkillAI level %d", ai.EffectiveLevel())
+}
+
+// NextMove returns the move (x, y) chosen at the AI's level.
+func (ai SkillAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	return ai.NextMoveWithBudget(board, me, players, 0)
+}
+
+// NextMoveWithBudget behaves like NextMove but stops searching once budget
+// has elapsed (0 uses the AI's Budget, a negative budget disables the time
+// limit).
+func (ai SkillAI) NextMoveWithBudget(board *game.Board, me *game.Player, players []*game.Player, budget time.Duration) (int, int) {
+	m, _, err := ai.Search(context.Background(), board, me, players, Limits{Budget: budget})
+	if err != nil {
+		return noMoveX, noMoveY
+	}
+	return m.X, m.Y
+}
+
+// Search implements Engine. limits.MaxDepth can only lower the depth of the
+// level. Info.Score is the search score of the chosen move, before noise.
+func (ai SkillAI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits Limits) (game.Move, Info, error) {
+	start := time.Now()
+	level := ai.EffectiveLevel()
+	if level == MaxSkillLevel {
+		return MinimaxAI{Budget: ai.Budget, Workers: ai.Workers}.Search(ctx, board, me, players, limits)
+	}
+	if err := ctx.Err(); err != nil {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, err
+	}
+
+	profile := skillProfiles[level-MinSkillLevel]
+	seed := ai.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	moves := board.AvailableMoves()
+	if len(moves) == 0 {
+		return game.Move{X: noMoveX, Y: noMoveY}, Info{}, ErrNoMove
+	}
+	if rng.Float64() < profile.blunder {
+		m := moves[rng.Intn(len(moves))]
+		return m, Info{PV: []game.Move{m}, Time: time.Since(start)}, nil
+	}
+
+	if limits.MaxDepth <= 0 || limits.MaxDepth > profile.depth {
+		limits.MaxDepth = profile.depth
+	}
+	if len(players) != 2 {
+		mp := MultiplayerAI{Mode: MODE_PARANOID, Budget: ai.Budget}
+		return mp.Search(ctx, board, me, players, limits)
+	}
+
+	budget := limits.Budget
+	if budget == 0 {
+		budget = ai.Budget
+	}
+	if budget == 0 {
+		budget = DefaultMoveBudget
+	}
+
+	m, info := noisySearch(ctx, board, me, players, limits.MaxDepth, start, budget, profile.noise, rng)
+	info.Time = time.Since(start)
+	if err := ctx.Err(); errors.Is(err, context.Canceled) {
+		return m, info, err
+	}
+	return m, info, nil
+}
+
+// noisySearch scores every move of me up to depth plies within budget, adds
+// Gaussian noise of standard deviation noise to the scores, and returns the
+// best move with the scores of the last completed depth.
+func noisySearch(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, depth int, start time.Time, budget time.Duration, noise float64, rng *rand.Rand) (game.Move, Info) {
+	table := DefaultTranspositionTable()
+	table.NewSearch()
+	s := newSearch(board, me, players, table)
+	s.ctx = ctx
+	if budget > 0 {
+		s.deadline = start.Add(budget)
+	}
+	if d, ok := ctx.Deadline(); ok && (s.deadline.IsZero() || d.Before(s.deadline)) {
+		s.deadline = d
+	}
+
+	candidates := board.UniqueMoves()
+	scores := make([]int, len(candidates))
+	var info Info
+	for d := 1; d <= min(depth, s.empty); d++ {
+		next := make([]int, len(candidates))
+		for i, m := range candidates {
+			next[i] = s.play(me, m, 0, d, initialLowerBound, initialUpperBound)
+			if s.aborted {
+				break
+			}
+		}
+		if s.aborted {
+			break
+		}
+		scores, info.Depth = next, d
+	}
+
+	best, bestValue := 0, math.Inf(-1)
+	for i, score := range scores {
+		if v := float64(score) + rng.NormFloat64()*noise; v > bestValue {
+			best, bestValue = i, v
+		}
+	}
+
+	m := candidates[best]
+	info.Score = scores[best]
+	info.Nodes = s.nodes
+	info.PV = []game.Move{m}
+	return m, info
+}
@@ -0,0 +1,57 @@
+/**
+ ******************************************************************************
+ * @file            : tablebase.go
+ * @brief           : GoTicTacToe - Tablebase AI implementation
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains an AI that plays perfectly from a table produced by
+ * the solver package (see cmd/solve). Positions missing from the table
+ * (other configuration, pruned table) are delegated to a fallback model.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"GoTicTacToe/solver"
+)
+
+// TablebaseAI is an AI player that looks up the best move in a solved table.
+//
+// It wins as fast as possible, and otherwise draws or loses as slowly as
+// possible. Table assumes players move in the order of their IDs.
+type TablebaseAI struct {
+	Table    *solver.Table // Solved table (nil to always use Fallback)
+	Fallback AIModel       // Model used when the table cannot help (MinimaxAI if nil)
+}
+
+// NewTablebaseAI loads the table stored at path and returns an AI using it.
+func NewTablebaseAI(path string) (TablebaseAI, error) {
+	table, err := solver.ReadFile(path)
+	if err != nil {
+		return TablebaseAI{}, err
+	}
+	return TablebaseAI{Table: table}, nil
+}
+
+// NextMove returns the table move for the current position, or the move of
+// the fallback model if the position is not in the table.
+func (ai TablebaseAI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	if ai.Table != nil {
+		if m, _, ok := ai.Table.BestMove(board, me.ID); ok {
+			return m.X, m.Y
+		}
+	}
+
+	fallback := ai.Fallback
+	if fallback == nil {
+		fallback = MinimaxAI{}
+	}
+	return fallback.NextMove(board, me, players)
+}
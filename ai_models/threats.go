@@ -0,0 +1,509 @@
+/**
+ ******************************************************************************
+ * @file            : threats.go
+ * @brief           : GoTicTacToe - Threat-space search (VCF / VCT)
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains a threat-space search for k-in-a-row games: it looks
+ * for forced wins made only of threats, where each attacking move leaves the
+ * defender a handful of replies. With k = WinLength:
+ *   - a four is a move after which the attacker wins at once unless the
+ *     defender blocks (k-1 tokens in a window),
+ *   - a three is a move after which the attacker threatens a double four,
+ *     a move creating two fours at once.
+ *
+ * Since the defender's replies are so few, the search sees wins far deeper
+ * than a full-width search (15+ plies on 15x15 boards). Only wins are
+ * reported, never losses: a win is reported when every defender reply that
+ * does not lose at once has been refuted, counter-fours included.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"sort"
+)
+
+// ThreatMode selects the threats a threat-space search may play.
+type ThreatMode int
+
+const (
+	// THREAT_VCF plays fours only (Victory by Continuous Fours).
+	THREAT_VCF ThreatMode = iota
+
+	// THREAT_VCT plays fours and threes (Victory by Continuous Threats).
+	THREAT_VCT
+)
+
+// DefaultThreatDepth is the number of plies of the threat-space pass of a
+// MinimaxAI without ThreatDepth.
+const DefaultThreatDepth = 21
+
+const (
+	// threatMaxNodes bounds the nodes of a single threat-space search.
+	threatMaxNodes = 200_000
+
+	// threatCheckInterval is the number of nodes between two checks of the
+	// context: threat nodes generate and classify all threats, so they are
+	// much slower than alpha-beta nodes.
+	threatCheckInterval = 16
+
+	// threatBudgetShare is the fraction (1/n) of the move budget given to
+	// the threat-space pass of MinimaxAI.
+	threatBudgetShare = 4
+)
+
+// ThreatWin looks for a forced win of attacker, to move, made only of
+// threats and at most maxPlies plies long (both sides' moves, the winning
+// move included).
+//
+// It returns the main line: the attacker's moves alternating with the
+// defender's replies, ending with the winning move. The result is false if
+// no win was found within maxPlies, threatMaxNodes nodes, or before ctx is
+// done; as quiet moves are never tried, this does not prove there is none.
+func ThreatWin(ctx context.Context, board *game.Board, attacker, defender *game.Player, mode ThreatMode, maxPlies int) ([]game.Move, bool) {
+	return newThreatSearch(ctx, board, attacker, defender, mode).find(maxPlies)
+}
+
+// threatSearch holds the state of a single threat-space search.
+//
+// The board is a private copy, modified in place with Play and Undo.
+type threatSearch struct {
+	board    *game.Board
+	attacker *game.Player
+	defender *game.Player
+	mode     ThreatMode
+	length   int // Tokens in a row to win
+
+	failed map[uint64]int // Plies searched without a win, by position hash
+	marks  []int          // Cells seen by windowCells, by stamp
+	stamp  int            // Current windowCells stamp
+	buf    []game.Move    // Empty cells of the last window
+
+	ctx     context.Context
+	nodes   int  // Nodes visited so far
+	aborted bool // Whether the node limit was hit or ctx is done
+}
+
+// newThreatSearch prepares a search on a copy of board.
+func newThreatSearch(ctx context.Context, board *game.Board, attacker, defender *game.Player, mode ThreatMode) *threatSearch {
+	return &threatSearch{
+		board:    board.Clone(),
+		attacker: attacker,
+		defender: defender,
+		mode:     mode,
+		length:   board.WinLength(),
+		failed:   map[uint64]int{},
+		marks:    make([]int, board.Width*board.Height),
+		ctx:      ctx,
+	}
+}
+
+// find deepens the search one threat (two plies) at a time, so that the
+// shortest win is found first.
+func (ts *threatSearch) find(maxPlies int) ([]game.Move, bool) {
+	wins, blocks := ts.wins(ts.attacker), ts.wins(ts.defender)
+	for plies := 1; plies <= maxPlies && !ts.aborted; plies += 2 {
+		if line, ok := ts.attack(plies, wins, blocks); ok {
+			return line, true
+		}
+	}
+	return nil, false
+}
+
+// visit counts a node and checks ctx every threatCheckInterval nodes.
+func (ts *threatSearch) visit() {
+	ts.nodes++
+	if ts.nodes >= threatMaxNodes {
+		ts.aborted = true
+	}
+	if ts.nodes%threatCheckInterval == 0 && ts.ctx.Err() != nil {
+		ts.aborted = true
+	}
+}
+
+// attack returns the winning line of the attacker, to move, within plies
+// plies. wins and blocks are the cells where the attacker and the defender
+// complete a line at once.
+func (ts *threatSearch) attack(plies int, wins, blocks []game.Move) ([]game.Move, bool) {
+	ts.visit()
+	if ts.aborted {
+		return nil, false
+	}
+
+	if len(wins) > 0 {
+		return wins[:1], true
+	}
+	// A threat, its reply and the win take at least three plies.
+	if plies < 3 {
+		return nil, false
+	}
+
+	key := ts.board.Hash()
+	if searched, ok := ts.failed[key]; ok && searched >= plies {
+		return nil, false
+	}
+
+	// A four of the defender must be blocked, and the block must itself be
+	// a threat for the attack to go on.
+	if len(blocks) < 2 {
+		for _, m := range ts.threats() {
+			if len(blocks) == 1 && m != blocks[0] {
+				continue
+			}
+
+			ts.board.Play(ts.attacker, m.X, m.Y)
+			line, ok := ts.defend(m, plies-1)
+			ts.board.Undo(m.X, m.Y)
+			if ok {
+				return append([]game.Move{m}, line...), true
+			}
+			if ts.aborted {
+				return nil, false
+			}
+		}
+	}
+
+	ts.failed[key] = plies
+	return nil, false
+}
+
+// defend returns the main line after the attacker's threat m, with the
+// defender to move, if every sensible reply loses within plies plies.
+//
+// The attacker had no win before m, and the defender none left after it:
+// the wins of the next attack can only go through m and the reply.
+func (ts *threatSearch) defend(m game.Move, plies int) ([]game.Move, bool) {
+	fours := ts.winsThrough(ts.attacker, m)
+	replies := fours
+	if len(replies) == 0 {
+		replies = ts.defences(m)
+	}
+
+	var line []game.Move
+	for _, d := range replies {
+		var wins []game.Move
+		for _, f := range fours {
+			if f != d {
+				wins = append(wins, f)
+			}
+		}
+
+		ts.board.Play(ts.defender, d.X, d.Y)
+		rest, ok := ts.attack(plies-1, wins, ts.winsThrough(ts.defender, d))
+		ts.board.Undo(d.X, d.Y)
+		if !ok {
+			return nil, false
+		}
+		if line == nil {
+			line = append([]game.Move{d}, rest...)
+		}
+	}
+	return line, line != nil
+}
+
+// threats returns the attacker's threats: fours first, then threes in
+// THREAT_VCT mode. The attacker must not have a win at once.
+func (ts *threatSearch) threats() []game.Move {
+	minStones := ts.length - 2
+	if ts.mode == THREAT_VCT {
+		minStones = ts.length - 3
+	}
+
+	var fours, threes []game.Move
+	for _, m := range ts.windowCells(ts.attacker, max(minStones, 0)) {
+		if len(ts.winsThrough(ts.attacker, m)) > 0 {
+			fours = append(fours, m)
+			continue
+		}
+		if ts.mode == THREAT_VCT {
+			ts.board.Play(ts.attacker, m.X, m.Y)
+			if len(ts.doubleFours(m)) > 0 {
+				threes = append(threes, m)
+			}
+			ts.board.Undo(m.X, m.Y)
+		}
+	}
+	return append(fours, threes...)
+}
+
+// defences returns the defender's replies to the attacker's three m: the
+// moves leaving no double four around m, and the counter-fours. If there
+// are none, the double fours are returned so that the loss is checked.
+func (ts *threatSearch) defences(m game.Move) []game.Move {
+	doubles := ts.doubleFours(m)
+
+	seen := map[game.Move]bool{}
+	var candidates []game.Move
+	add := func(c game.Move) {
+		if !seen[c] {
+			seen[c] = true
+			candidates = append(candidates, c)
+		}
+	}
+	for _, c := range doubles {
+		add(c)
+		for _, w := range ts.windowsThrough(ts.attacker, c) {
+			for _, e := range w {
+				add(e)
+			}
+		}
+	}
+	for _, c := range ts.windowCells(ts.defender, max(ts.length-2, 0)) {
+		add(c)
+	}
+
+	var replies []game.Move
+	for _, d := range candidates {
+		counter := len(ts.winsThrough(ts.defender, d)) > 0
+		ts.board.Play(ts.defender, d.X, d.Y)
+		if counter || len(ts.doubleFours(m)) == 0 {
+			replies = append(replies, d)
+		}
+		ts.board.Undo(d.X, d.Y)
+	}
+	if len(replies) == 0 {
+		return doubles
+	}
+	return replies
+}
+
+// doubleFours returns the empty cells where the attacker would make two
+// fours at once, one of them in a window through m.
+func (ts *threatSearch) doubleFours(m game.Move) []game.Move {
+	var doubles []game.Move
+	for _, dir := range evalDirections {
+		for offset := 0; offset < ts.length; offset++ {
+			start := game.Move{X: m.X - dir.DX*offset, Y: m.Y - dir.DY*offset}
+			empty, ok := ts.window(ts.attacker, start, dir)
+			if !ok || len(empty) != 2 {
+				continue
+			}
+
+			pair := [2]game.Move{empty[0], empty[1]}
+			for _, c := range pair {
+				if !contains(doubles, c) && len(ts.winsThrough(ts.attacker, c)) >= 2 {
+					doubles = append(doubles, c)
+				}
+			}
+		}
+	}
+	return doubles
+}
+
+// wins returns the empty cells where p completes a line at once.
+func (ts *threatSearch) wins(p *game.Player) []game.Move {
+	return ts.windowCells(p, ts.length-1)
+}
+
+// winsThrough returns the cells where p completes a line through c once c
+// holds a token of p, counting only the lines through c. The cell c must be
+// empty or p's.
+func (ts *threatSearch) winsThrough(p *game.Player, c game.Move) []game.Move {
+	var wins []game.Move
+	for _, dir := range evalDirections {
+		for offset := 0; offset < ts.length; offset++ {
+			start := game.Move{X: c.X - dir.DX*offset, Y: c.Y - dir.DY*offset}
+			empty, ok := ts.window(p, start, dir)
+			if !ok {
+				continue
+			}
+
+			var e game.Move
+			others := 0
+			for _, o := range empty {
+				if o != c {
+					e = o
+					others++
+				}
+			}
+			if others == 1 && !contains(wins, e) {
+				wins = append(wins, e)
+			}
+		}
+	}
+	return wins
+}
+
+// windowsThrough returns, for every window through the empty cell c that
+// holds no token but p's, its empty cells other than c.
+func (ts *threatSearch) windowsThrough(p *game.Player, c game.Move) [][]game.Move {
+	var windows [][]game.Move
+	for _, dir := range evalDirections {
+		for offset := 0; offset < ts.length; offset++ {
+			start := game.Move{X: c.X - dir.DX*offset, Y: c.Y - dir.DY*offset}
+			empty, ok := ts.window(p, start, dir)
+			if !ok {
+				continue
+			}
+
+			others := make([]game.Move, 0, len(empty)-1)
+			for _, e := range empty {
+				if e != c {
+					others = append(others, e)
+				}
+			}
+			windows = append(windows, others)
+		}
+	}
+	return windows
+}
+
+// windowCells returns the empty cells of the windows holding no token but
+// p's and at least minStones of them, each cell once.
+func (ts *threatSearch) windowCells(p *game.Player, minStones int) []game.Move {
+	b := ts.board
+	ts.stamp++
+	var cells []game.Move
+
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			for _, dir := range evalDirections {
+				empty, ok := ts.window(p, game.Move{X: x, Y: y}, dir)
+				if !ok || ts.length-len(empty) < minStones {
+					continue
+				}
+				for _, e := range empty {
+					if i := e.X*b.Height + e.Y; ts.marks[i] != ts.stamp {
+						ts.marks[i] = ts.stamp
+						cells = append(cells, e)
+					}
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// window returns the empty cells of the window starting at start in
+// direction dir, and false if it does not fit on the board or holds a token
+// of another player than p. The slice is reused by the next call.
+func (ts *threatSearch) window(p *game.Player, start game.Move, dir game.Direction) ([]game.Move, bool) {
+	b := ts.board
+	endX, endY := start.X+dir.DX*(ts.length-1), start.Y+dir.DY*(ts.length-1)
+	if start.X < 0 || start.Y < 0 || start.X >= b.Width || start.Y >= b.Height ||
+		endX < 0 || endY < 0 || endX >= b.Width || endY >= b.Height {
+		return nil, false
+	}
+
+	empty := ts.buf[:0]
+	for step := 0; step < ts.length; step++ {
+		x, y := start.X+dir.DX*step, start.Y+dir.DY*step
+		switch b.Cells[x][y] {
+		case nil:
+			empty = append(empty, game.Move{X: x, Y: y})
+		case p:
+		default:
+			return nil, false
+		}
+	}
+	ts.buf = empty
+	return empty, true
+}
+
+// empty reports whether c is an empty cell of the board.
+func (ts *threatSearch) empty(c game.Move) bool {
+	b := ts.board
+	return c.X >= 0 && c.Y >= 0 && c.X < b.Width && c.Y < b.Height && b.Cells[c.X][c.Y] == nil
+}
+
+// contains reports whether moves holds m.
+func contains(moves []game.Move, m game.Move) bool {
+	for _, o := range moves {
+		if o == m {
+			return true
+		}
+	}
+	return false
+}
+
+// threatSpacePass runs the threat-space searches of MinimaxAI before its
+// general search, at most maxPlies plies deep.
+//
+// It returns the winning line of me if there is one. Otherwise, if opp has
+// a threat-space win (as if opp were to move), it returns the candidates
+// known to refute it: all of them if none does, or if the searches ran out
+// of time before finding one. The last result is the number of nodes
+// visited.
+func threatSpacePass(ctx context.Context, board *game.Board, me, opp *game.Player, candidates []game.Move, maxPlies int) ([]game.Move, []game.Move, int) {
+	nodes := 0
+	for _, mode := range [...]ThreatMode{THREAT_VCF, THREAT_VCT} {
+		ts := newThreatSearch(ctx, board, me, opp, mode)
+		line, ok := ts.find(maxPlies)
+		nodes += ts.nodes
+		if ok {
+			return line, candidates, nodes
+		}
+	}
+
+	b := board.Clone()
+	for _, mode := range [...]ThreatMode{THREAT_VCF, THREAT_VCT} {
+		ts := newThreatSearch(ctx, b, opp, me, mode)
+		line, threatened := ts.find(maxPlies)
+		nodes += ts.nodes
+		if ts.aborted {
+			break
+		}
+		if !threatened {
+			continue
+		}
+
+		var safe []game.Move
+		for _, m := range refutationOrder(b, candidates, line) {
+			if b.WinsWith(me, m.X, m.Y) {
+				safe = append(safe, m)
+				continue
+			}
+
+			b.Play(me, m.X, m.Y)
+			ts := newThreatSearch(ctx, b, opp, me, mode)
+			_, lost := ts.find(maxPlies)
+			b.Undo(m.X, m.Y)
+			nodes += ts.nodes
+			if ts.aborted {
+				break
+			}
+			if !lost {
+				safe = append(safe, m)
+			}
+		}
+		if len(safe) > 0 {
+			return nil, safe, nodes
+		}
+		break
+	}
+	return nil, candidates, nodes
+}
+
+// refutationOrder returns candidates sorted so that the moves most likely
+// to refute the threat line come first: its own cells, then the cells
+// sharing a window with them.
+func refutationOrder(board *game.Board, candidates []game.Move, line []game.Move) []game.Move {
+	length := board.WinLength()
+	priority := map[game.Move]int{}
+	for _, c := range line {
+		for _, dir := range evalDirections {
+			for step := 1 - length; step < length; step++ {
+				m := game.Move{X: c.X + dir.DX*step, Y: c.Y + dir.DY*step}
+				priority[m] = max(priority[m], 1)
+			}
+		}
+	}
+	for _, c := range line {
+		priority[c] = 2
+	}
+
+	ordered := append([]game.Move(nil), candidates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return priority[ordered[i]] > priority[ordered[j]]
+	})
+	return ordered
+}
@@ -0,0 +1,172 @@
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"context"
+	"testing"
+)
+
+// Threat-space test positions (9x9 boards, five in a row, a to move).
+const (
+	// vcfPosition is won by a chain of eight fours (17 plies) and by no
+	// shorter one.
+	vcfPosition = "9x9/5 1b1b5/4b4/2b1a4/3a5/8b/3ba4/2aa2a2/9/9 a 2 -"
+
+	// vctPosition holds two crossing twos of a: e5 makes a double three,
+	// which no four can answer.
+	vctPosition = "9x9/5 b7b/9/4a4/4a4/2aa5/9/9/9/b7b a 2 -"
+
+	// openThreePosition holds an open three of b (c5 to g5), which only c5
+	// and g5 refute.
+	openThreePosition = "9x9/5 a7a/9/9/9/3bbb3/9/9/9/a8 a 2 -"
+)
+
+// threatGame returns the game of a threat test position.
+func threatGame(t *testing.T, notation string) *game.Game {
+	t.Helper()
+	g := game.NewGame()
+	if err := g.Decode(notation); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// parseMoves parses moves in coordinate notation.
+func parseMoves(t *testing.T, coords ...string) []game.Move {
+	t.Helper()
+	moves := make([]game.Move, len(coords))
+	for i, c := range coords {
+		m, err := game.ParseMove(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		moves[i] = m
+	}
+	return moves
+}
+
+// immediateWins returns the empty cells where p completes a line at once.
+func immediateWins(b *game.Board, p *game.Player) []game.Move {
+	var wins []game.Move
+	for _, m := range b.AvailableMoves() {
+		if b.WinsWith(p, m.X, m.Y) {
+			wins = append(wins, m)
+		}
+	}
+	return wins
+}
+
+// checkFourLine checks that line is a forced win of attacker made of fours,
+// independently of the threat-space search: before each reply, the defender
+// has no win of its own and the attacker threatens a single cell, which the
+// reply blocks, or several, so that any reply loses. The last move must win.
+func checkFourLine(t *testing.T, board *game.Board, attacker, defender *game.Player, line []game.Move) {
+	t.Helper()
+	b := board.Clone()
+	for i, m := range line {
+		if i%2 == 1 {
+			if wins := immediateWins(b, defender); len(wins) > 0 {
+				t.Fatalf("ply %d: the defender wins at %v", i+1, wins[0])
+			}
+			threats := immediateWins(b, attacker)
+			if len(threats) == 0 || (len(threats) == 1 && threats[0] != m) {
+				t.Fatalf("ply %d: reply %v is not forced (attacker threatens %v)", i+1, m, threats)
+			}
+			b.Play(defender, m.X, m.Y)
+			continue
+		}
+
+		if i == len(line)-1 {
+			if !b.WinsWith(attacker, m.X, m.Y) {
+				t.Fatalf("last move %v does not win", m)
+			}
+			return
+		}
+		// A counter-four of the defender must be blocked first.
+		if wins := immediateWins(b, defender); len(wins) > 0 && (len(wins) > 1 || wins[0] != m) {
+			t.Fatalf("ply %d: %v ignores the defender's win at %v", i+1, m, wins[0])
+		}
+		if !b.Play(attacker, m.X, m.Y) {
+			t.Fatalf("ply %d: %v is not playable", i+1, m)
+		}
+	}
+	t.Fatal("line does not end with the attacker's move")
+}
+
+func TestThreatWinVCF(t *testing.T) {
+	g := threatGame(t, vcfPosition)
+	me, opp := g.Players[0], g.Players[1]
+
+	line, ok := ThreatWin(context.Background(), g.Board, me, opp, THREAT_VCF, DefaultThreatDepth)
+	if !ok {
+		t.Fatal("no VCF found")
+	}
+	if len(line) != 17 {
+		t.Errorf("VCF of %d plies %v, want 17", len(line), line)
+	}
+	checkFourLine(t, g.Board, me, opp, line)
+
+	if line, ok := ThreatWin(context.Background(), g.Board, me, opp, THREAT_VCF, 15); ok {
+		t.Errorf("VCF of %d plies %v found within 15 plies", len(line), line)
+	}
+}
+
+func TestThreatWinVCT(t *testing.T) {
+	g := threatGame(t, vctPosition)
+	me, opp := g.Players[0], g.Players[1]
+
+	if line, ok := ThreatWin(context.Background(), g.Board, me, opp, THREAT_VCF, DefaultThreatDepth); ok {
+		t.Fatalf("VCF %v found without any three on the board", line)
+	}
+
+	// Double three, block, open four, block, five.
+	line, ok := ThreatWin(context.Background(), g.Board, me, opp, THREAT_VCT, DefaultThreatDepth)
+	if !ok {
+		t.Fatal("no VCT found")
+	}
+	if len(line) != 5 || line[0] != parseMoves(t, "e5")[0] {
+		t.Fatalf("VCT %v, want 5 plies starting with e5", line)
+	}
+	// After the double three, the rest of the line is made of fours.
+	b := g.Board.Clone()
+	b.Play(me, line[0].X, line[0].Y)
+	b.Play(opp, line[1].X, line[1].Y)
+	checkFourLine(t, b, me, opp, line[2:])
+}
+
+func TestThreatSpacePass(t *testing.T) {
+	t.Run("win", func(t *testing.T) {
+		g := threatGame(t, vcfPosition)
+		candidates := g.Board.AvailableMoves()
+		line, safe, _ := threatSpacePass(context.Background(), g.Board, g.Players[0], g.Players[1], candidates, DefaultThreatDepth)
+		if line == nil {
+			t.Fatal("threat-space pass missed the win")
+		}
+		if len(safe) != len(candidates) {
+			t.Errorf("winning pass restricted the candidates to %v", safe)
+		}
+	})
+
+	t.Run("refutations", func(t *testing.T) {
+		g := threatGame(t, openThreePosition)
+		line, safe, _ := threatSpacePass(context.Background(), g.Board, g.Players[0], g.Players[1],
+			g.Board.AvailableMoves(), DefaultThreatDepth)
+		if line != nil {
+			t.Fatalf("threat-space pass found a win %v", line)
+		}
+
+		want := map[game.Move]bool{}
+		for _, m := range parseMoves(t, "c5", "g5") {
+			want[m] = true
+		}
+		if len(safe) != len(want) || !want[safe[0]] || !want[safe[1]] {
+			t.Errorf("refutations %v, want c5 and g5", safe)
+		}
+
+		ai := MinimaxAI{Table: NewTranspositionTable(testTableBytes), Budget: -1, Workers: 1}
+		m, _, err := ai.Search(context.Background(), g.Board, g.Current, g.Players, Limits{MaxDepth: 2})
+		if err != nil || !want[m] {
+			t.Errorf("MinimaxAI plays %v (%v), want c5 or g5", m, err)
+		}
+	})
+}
@@ -0,0 +1,293 @@
+/**
+ ******************************************************************************
+ * @file            : transposition.go
+ * @brief           : GoTicTacToe - Transposition table for the search AIs
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains a fixed-size transposition table. It remembers the
+ * result of searched positions (score, bound type, search depth and best
+ * move) so that positions reached again, through another move order or in a
+ * later NextMove call, are not searched twice.
+ *
+ * The table is made of two-entry buckets: the first entry keeps the deepest
+ * search (unless it belongs to an older search), the second one always takes
+ * the newest result.
+ *
+ * Buckets are guarded by a fixed set of locks (a bucket uses the lock of its
+ * index modulo ttLockShards), so that parallel search workers (see
+ * parallel.go) rarely wait for each other.
+ ******************************************************************************
+ */
+
+package ai_models
+
+import (
+	"GoTicTacToe/game"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Transposition table sizing.
+const (
+	// DefaultTableBytes is the memory budget of the shared default table.
+	DefaultTableBytes = 16 << 20
+
+	// ttBucketEntries is the number of entries per bucket.
+	ttBucketEntries = 2
+
+	// ttEntryBytes is the memory used by a single entry.
+	ttEntryBytes = int(unsafe.Sizeof(ttEntry{}))
+
+	// ttLockShards is the number of locks guarding the buckets (a power of
+	// two).
+	ttLockShards = 256
+)
+
+// Salt mixing constants (SplitMix64 finalizer).
+const (
+	saltMulA uint64 = 0xBF58476D1CE4E5B9
+	saltMulB uint64 = 0x94D049BB133111EB
+)
+
+// Bound tells how a stored score relates to the true score of a position.
+type Bound uint8
+
+const (
+	// BOUND_NONE marks an empty entry.
+	BOUND_NONE Bound = iota
+
+	// BOUND_EXACT indicates that the score is the true score.
+	BOUND_EXACT
+
+	// BOUND_LOWER indicates that the true score is at least the score
+	// (the search failed high).
+	BOUND_LOWER
+
+	// BOUND_UPPER indicates that the true score is at most the score
+	// (the search failed low).
+	BOUND_UPPER
+)
+
+// TTEntry is the stored result of a searched position.
+type TTEntry struct {
+	Depth int       // Plies searched below the position
+	Bound Bound     // How Score relates to the true score
+	Score int       // Score from the point of view of the searching player
+	Move  game.Move // Best move found (X is -1 if none)
+}
+
+// TTStats are the usage counters of a transposition table.
+type TTStats struct {
+	Probes       uint64 // Lookups
+	Hits         uint64 // Lookups that found the position
+	Stores       uint64 // Results written
+	Replacements uint64 // Writes that evicted another position
+	Used         int    // Entries holding a position
+	Capacity     int    // Total number of entries
+}
+
+// HitRate returns the fraction of lookups that found the position.
+func (s TTStats) HitRate() float64 {
+	if s.Probes == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Probes)
+}
+
+// ttEntry is the packed form of TTEntry.
+type ttEntry struct {
+	key        uint64
+	score      int32
+	depth      uint8
+	bound      Bound
+	generation uint8
+	moveX      int8
+	moveY      int8
+}
+
+// TranspositionTable is a fixed-size hash table of search results.
+//
+// It is safe for concurrent use and is meant to be shared by every search of
+// a session: a new search only ages the existing entries.
+type TranspositionTable struct {
+	locks      [ttLockShards]sync.Mutex
+	entries    []ttEntry
+	mask       uint64       // Bucket count - 1 (the count is a power of two)
+	generation atomic.Int32 // Current search, to age older entries (low 8 bits)
+	used       atomic.Int64
+
+	probes       atomic.Uint64
+	hits         atomic.Uint64
+	stores       atomic.Uint64
+	replacements atomic.Uint64
+}
+
+var (
+	defaultTable     *TranspositionTable
+	defaultTableOnce sync.Once
+)
+
+// DefaultTranspositionTable returns the table shared by the search AIs that
+// were not given their own, created on first use with DefaultTableBytes.
+func DefaultTranspositionTable() *TranspositionTable {
+	defaultTableOnce.Do(func() {
+		defaultTable = NewTranspositionTable(DefaultTableBytes)
+	})
+	return defaultTable
+}
+
+// NewTranspositionTable returns an empty table using at most budget bytes
+// (and at least one bucket).
+func NewTranspositionTable(budget int) *TranspositionTable {
+	buckets := 1
+	for buckets*2*ttBucketEntries*ttEntryBytes <= budget {
+		buckets *= 2
+	}
+	return &TranspositionTable{
+		entries: make([]ttEntry, buckets*ttBucketEntries),
+		mask:    uint64(buckets - 1),
+	}
+}
+
+// NewSearch marks the start of a new search: entries stored by previous
+// searches stay usable but become the first to be replaced.
+func (t *TranspositionTable) NewSearch() {
+	t.generation.Add(1)
+}
+
+// Clear removes every entry and resets the statistics.
+func (t *TranspositionTable) Clear() {
+	for i := range t.locks {
+		t.locks[i].Lock()
+	}
+	clear(t.entries)
+	t.used.Store(0)
+	for i := range t.locks {
+		t.locks[i].Unlock()
+	}
+
+	t.probes.Store(0)
+	t.hits.Store(0)
+	t.stores.Store(0)
+	t.replacements.Store(0)
+}
+
+// Probe returns the entry stored for key, if any.
+func (t *TranspositionTable) Probe(key uint64) (TTEntry, bool) {
+	t.probes.Add(1)
+
+	mu := t.lock(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	bucket := t.bucket(key)
+	for i := range bucket {
+		e := &bucket[i]
+		if e.bound != BOUND_NONE && e.key == key {
+			t.hits.Add(1)
+			return TTEntry{
+				Depth: int(e.depth),
+				Bound: e.bound,
+				Score: int(e.score),
+				Move:  game.Move{X: int(e.moveX), Y: int(e.moveY)},
+			}, true
+		}
+	}
+	return TTEntry{}, false
+}
+
+// Store records the result of a search of depth plies for key.
+//
+// The first entry of the bucket is replaced by the same position, by an
+// entry of an older search or by a search at least as deep; otherwise the
+// second entry is overwritten.
+func (t *TranspositionTable) Store(key uint64, depth int, bound Bound, score int, move game.Move) {
+	t.stores.Add(1)
+	generation := uint8(t.generation.Load())
+
+	mu := t.lock(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	bucket := t.bucket(key)
+	slot := &bucket[len(bucket)-1]
+	for i := range bucket {
+		if bucket[i].key == key && bucket[i].bound != BOUND_NONE {
+			slot = &bucket[i]
+			break
+		}
+	}
+	if first := &bucket[0]; slot != first && slot.key != key {
+		if first.bound == BOUND_NONE || first.generation != generation || depth >= int(first.depth) {
+			slot = first
+		}
+	}
+
+	switch {
+	case slot.bound == BOUND_NONE:
+		t.used.Add(1)
+	case slot.key != key:
+		t.replacements.Add(1)
+	}
+
+	*slot = ttEntry{
+		key:        key,
+		score:      int32(score),
+		depth:      uint8(min(depth, 255)),
+		bound:      bound,
+		generation: generation,
+		moveX:      int8(move.X),
+		moveY:      int8(move.Y),
+	}
+}
+
+// Stats returns the current usage counters.
+func (t *TranspositionTable) Stats() TTStats {
+	return TTStats{
+		Probes:       t.probes.Load(),
+		Hits:         t.hits.Load(),
+		Stores:       t.stores.Load(),
+		Replacements: t.replacements.Load(),
+		Used:         int(t.used.Load()),
+		Capacity:     len(t.entries),
+	}
+}
+
+// lock returns the lock guarding the bucket of key.
+func (t *TranspositionTable) lock(key uint64) *sync.Mutex {
+	return &t.locks[(key&t.mask)%ttLockShards]
+}
+
+// bucket returns the entries where key may be stored.
+func (t *TranspositionTable) bucket(key uint64) []ttEntry {
+	start := (key & t.mask) * ttBucketEntries
+	return t.entries[start : start+ttBucketEntries]
+}
+
+// positionKey returns the table key of board when toMove is to play and me
+// is the searching player.
+//
+// The board hash only covers the tokens; the salt adds the board shape and
+// the two players so that searches of different games sharing a table
+// cannot mix their results.
+func positionKey(board *game.Board, me, toMove *game.Player) uint64 {
+	salt := uint64(board.Width)
+	salt = salt<<8 | uint64(board.Height)
+	salt = salt<<8 | uint64(board.ToWin)
+	salt = salt<<8 | uint64(uint8(me.ID))
+	salt = salt<<8 | uint64(uint8(toMove.ID))
+	return board.Hash() ^ mixSalt(salt)
+}
+
+// mixSalt spreads the bits of salt over the whole key.
+func mixSalt(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * saltMulA
+	z = (z ^ (z >> 27)) * saltMulB
+	return z ^ (z >> 31)
+}
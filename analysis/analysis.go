@@ -0,0 +1,183 @@
+/**
+ ******************************************************************************
+ * @file            : analysis.go
+ * @brief           : GoTicTacToe - Static position analysis
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the static analysis of a position: the windows of
+ * WinLength cells each player can still fill, the cells that win at once,
+ * the cells that must be blocked and the moves creating a double threat.
+ * Nothing here looks ahead more than one move; see forced.go for search.
+ ******************************************************************************
+ */
+
+// Package analysis extracts tactical information from a board position:
+// open lines, threats, forks and short forced wins.
+package analysis
+
+import "GoTicTacToe/game"
+
+// directions are the 4 line directions a window can follow.
+var directions = [...]game.Direction{
+	{DX: 1, DY: 0},  // horizontal (→)
+	{DX: 0, DY: 1},  // vertical (↓)
+	{DX: 1, DY: 1},  // diagonal down-right (↘)
+	{DX: 1, DY: -1}, // diagonal up-right (↗)
+}
+
+// Window is a run of WinLength consecutive cells that a player can still
+// fill: it holds no token of any other player.
+type Window struct {
+	Start     game.Move      // First cell of the window
+	Direction game.Direction // Step from one cell to the next
+	Length    int            // Number of cells (the board's WinLength)
+	Stones    int            // Tokens of the owner already in the window
+	Empty     []game.Move    // Cells still to fill, in window order
+}
+
+// Cells returns the cells of the window, in order.
+func (w Window) Cells() []game.Move {
+	cells := make([]game.Move, w.Length)
+	for i := range cells {
+		cells[i] = game.Move{X: w.Start.X + i*w.Direction.DX, Y: w.Start.Y + i*w.Direction.DY}
+	}
+	return cells
+}
+
+// Report gathers the analysis of a position for one player.
+type Report struct {
+	Player  *game.Player
+	Windows []Window    // Open windows (see OpenWindows)
+	Wins    []game.Move // Cells that win at once (see ImmediateWins)
+	Blocks  []game.Move // Cells stopping an opponent's immediate win (see MustBlock)
+	Forks   []game.Move // Cells creating a double threat (see Forks)
+}
+
+// Analyze returns the report of every player, in the order of players.
+func Analyze(board *game.Board, players []*game.Player) []Report {
+	reports := make([]Report, len(players))
+	for i, p := range players {
+		reports[i] = Report{
+			Player:  p,
+			Windows: OpenWindows(board, p),
+			Wins:    ImmediateWins(board, p),
+			Blocks:  MustBlock(board, p, players),
+			Forks:   Forks(board, p),
+		}
+	}
+	return reports
+}
+
+// OpenWindows lists the windows p can still complete, whatever their number
+// of stones (an empty window is open for every player).
+func OpenWindows(board *game.Board, p *game.Player) []Window {
+	var windows []Window
+	length := board.WinLength()
+
+	for x := 0; x < board.Width; x++ {
+		for y := 0; y < board.Height; y++ {
+			for _, dir := range directions {
+				if w, ok := windowAt(board, p, game.Move{X: x, Y: y}, dir, length); ok {
+					windows = append(windows, w)
+				}
+			}
+		}
+	}
+	return windows
+}
+
+// ImmediateWins returns the empty cells where p completes a line at once.
+func ImmediateWins(board *game.Board, p *game.Player) []game.Move {
+	var wins []game.Move
+	seen := map[game.Move]bool{}
+
+	for _, w := range OpenWindows(board, p) {
+		if len(w.Empty) != 1 || seen[w.Empty[0]] {
+			continue
+		}
+		seen[w.Empty[0]] = true
+		wins = append(wins, w.Empty[0])
+	}
+	return wins
+}
+
+// MustBlock returns the cells where another player would win at once, and
+// which p must therefore occupy (or win first) to survive.
+//
+// With several opponents, every opponent's winning cells are listed; the
+// most urgent ones belong to the next player in turn order.
+func MustBlock(board *game.Board, p *game.Player, players []*game.Player) []game.Move {
+	var blocks []game.Move
+	seen := map[game.Move]bool{}
+
+	for _, opp := range players {
+		if opp == p {
+			continue
+		}
+		for _, m := range ImmediateWins(board, opp) {
+			if !seen[m] {
+				seen[m] = true
+				blocks = append(blocks, m)
+			}
+		}
+	}
+	return blocks
+}
+
+// IsFork reports whether p already has a double threat: two or more distinct
+// cells that win at once, which a single opponent cannot both block.
+func IsFork(board *game.Board, p *game.Player) bool {
+	return len(ImmediateWins(board, p)) >= 2
+}
+
+// Forks returns the empty cells where p creates a double threat without
+// winning at once. The moves are tried on a copy: board is not modified.
+func Forks(board *game.Board, p *game.Player) []game.Move {
+	wins := map[game.Move]bool{}
+	for _, m := range ImmediateWins(board, p) {
+		wins[m] = true
+	}
+
+	scratch := board.Clone()
+	var forks []game.Move
+	for _, m := range board.AvailableMoves() {
+		if wins[m] {
+			continue
+		}
+		scratch.Play(p, m.X, m.Y)
+		if IsFork(scratch, p) {
+			forks = append(forks, m)
+		}
+		scratch.Undo(m.X, m.Y)
+	}
+	return forks
+}
+
+// windowAt returns the window of length cells starting at start in
+// direction dir, if it fits on the board and holds no token but p's.
+func windowAt(board *game.Board, p *game.Player, start game.Move, dir game.Direction, length int) (Window, bool) {
+	endX := start.X + dir.DX*(length-1)
+	endY := start.Y + dir.DY*(length-1)
+	if endX < 0 || endY < 0 || endX >= board.Width || endY >= board.Height {
+		return Window{}, false
+	}
+
+	w := Window{Start: start, Direction: dir, Length: length}
+	for i := 0; i < length; i++ {
+		x, y := start.X+i*dir.DX, start.Y+i*dir.DY
+		switch board.Cells[x][y] {
+		case nil:
+			w.Empty = append(w.Empty, game.Move{X: x, Y: y})
+		case p:
+			w.Stones++
+		default:
+			return Window{}, false
+		}
+	}
+	return w, true
+}
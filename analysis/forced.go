@@ -0,0 +1,180 @@
+/**
+ ******************************************************************************
+ * @file            : forced.go
+ * @brief           : GoTicTacToe - Forced-win search
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the forced-win search: an AND/OR search deciding
+ * whether a player can win within a number of plies whatever the other
+ * players reply. Results are cached per position (Zobrist hash), side to
+ * move and remaining depth.
+ ******************************************************************************
+ */
+
+package analysis
+
+import "GoTicTacToe/game"
+
+// ForcedWin returns the first move of the fastest forced win of p, with p to
+// move, within maxPlies plies (p's moves and the replies in between).
+//
+// Players move in the order of players, starting with p. It returns the
+// number of plies of the win, or false if no forced win exists in maxPlies.
+func ForcedWin(board *game.Board, players []*game.Player, p *game.Player, maxPlies int) (game.Move, int, bool) {
+	s := newForcedSearch(board, players, p)
+	if s == nil {
+		return game.Move{}, 0, false
+	}
+
+	// Winning plies are odd counts of plies in a two-player game, but with more
+	// players p moves every len(players) plies: try each of p's turns in order.
+	for plies := 1; plies <= maxPlies; plies += len(players) {
+		for _, m := range board.AvailableMoves() {
+			if s.winningMove(m, plies) {
+				return m, plies, true
+			}
+		}
+	}
+	return game.Move{}, 0, false
+}
+
+// WinningMoves returns every move of p, with p to move, that forces a win
+// within maxPlies plies (see ForcedWin).
+func WinningMoves(board *game.Board, players []*game.Player, p *game.Player, maxPlies int) []game.Move {
+	s := newForcedSearch(board, players, p)
+	if s == nil {
+		return nil
+	}
+
+	var moves []game.Move
+	for _, m := range board.AvailableMoves() {
+		if s.winningMove(m, maxPlies) {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+// HasForcedWin reports whether p, with p to move, can force a win within
+// maxPlies plies.
+func HasForcedWin(board *game.Board, players []*game.Player, p *game.Player, maxPlies int) bool {
+	_, _, ok := ForcedWin(board, players, p, maxPlies)
+	return ok
+}
+
+// forcedKey identifies a search node in the cache.
+type forcedKey struct {
+	hash  uint64 // Board.Hash of the position
+	turn  int    // Index of the side to move in players
+	plies int    // Remaining plies
+}
+
+// forcedSearch holds the state of a forced-win search on a private board.
+type forcedSearch struct {
+	board    *game.Board
+	players  []*game.Player
+	attacker int // Index of the player trying to win
+	cache    map[forcedKey]bool
+}
+
+// newForcedSearch prepares a search for p, or returns nil if p is not one of
+// players or the position is already over.
+func newForcedSearch(board *game.Board, players []*game.Player, p *game.Player) *forcedSearch {
+	attacker := -1
+	for i, candidate := range players {
+		if candidate == p {
+			attacker = i
+		}
+	}
+	if attacker < 0 || board.CheckWin() != nil {
+		return nil
+	}
+
+	return &forcedSearch{
+		board:    board.Clone(),
+		players:  players,
+		attacker: attacker,
+		cache:    map[forcedKey]bool{},
+	}
+}
+
+// winningMove reports whether the attacker playing m forces a win within
+// plies plies (m included).
+func (s *forcedSearch) winningMove(m game.Move, plies int) bool {
+	if plies < 1 || !s.board.Play(s.players[s.attacker], m.X, m.Y) {
+		return false
+	}
+	defer s.board.Undo(m.X, m.Y)
+
+	if s.board.CheckWin() != nil {
+		return true
+	}
+	return s.wins(s.next(s.attacker), plies-1)
+}
+
+// wins reports whether the attacker forces a win within plies plies, with
+// players[turn] to move.
+func (s *forcedSearch) wins(turn, plies int) bool {
+	// The attacker needs at least one more move of its own.
+	if plies < s.pliesToAttacker(turn)+1 || s.board.EmptyCount() == 0 {
+		return false
+	}
+
+	key := forcedKey{hash: s.board.Hash(), turn: turn, plies: plies}
+	if v, ok := s.cache[key]; ok {
+		return v
+	}
+
+	var result bool
+	if turn == s.attacker {
+		result = s.attackerWins(plies)
+	} else {
+		result = s.defenceFails(turn, plies)
+	}
+
+	s.cache[key] = result
+	return result
+}
+
+// attackerWins reports whether some attacker move forces a win (OR node).
+func (s *forcedSearch) attackerWins(plies int) bool {
+	for _, m := range s.board.AvailableMoves() {
+		if s.winningMove(m, plies) {
+			return true
+		}
+	}
+	return false
+}
+
+// defenceFails reports whether every move of players[turn] still loses
+// (AND node). A defender that completes its own line refutes the attack.
+func (s *forcedSearch) defenceFails(turn, plies int) bool {
+	defender := s.players[turn]
+	for _, m := range s.board.AvailableMoves() {
+		s.board.Play(defender, m.X, m.Y)
+		refuted := s.board.CheckWin() != nil || !s.wins(s.next(turn), plies-1)
+		s.board.Undo(m.X, m.Y)
+
+		if refuted {
+			return false
+		}
+	}
+	return true
+}
+
+// next returns the index of the player after turn.
+func (s *forcedSearch) next(turn int) int {
+	return (turn + 1) % len(s.players)
+}
+
+// pliesToAttacker returns how many plies are played before the attacker's
+// next move, with players[turn] to move.
+func (s *forcedSearch) pliesToAttacker(turn int) int {
+	n := len(s.players)
+	return (s.attacker - turn + n) % n
+}
@@ -1,29 +1,13 @@
 package assets
 
 import (
+	"GoTicTacToe/game"
 	"image/color"
 
 	"github.com/fogleman/gg"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// SymbolType identifies which symbol shape to generate and render.
-type SymbolType int
-
-const (
-	// CrossSymbol represents a "X" symbol.
-	CrossSymbol SymbolType = iota
-
-	// CircleSymbol represents an "O" symbol.
-	CircleSymbol
-
-	// TriangleSymbol represents a triangle symbol.
-	TriangleSymbol
-
-	// SquareSymbol represents a square symbol.
-	SquareSymbol
-)
-
 // Procedural symbol rendering constants (in pixels).
 const (
 	// symbolImageSizePx is the width and height of the generated symbol image.
@@ -35,9 +19,10 @@ const (
 
 // Symbol represents a renderable game symbol (shape + generated image).
 //
-// The Image field contains an Ebiten image ready to be drawn in the UI.
+// The shape is a game.SymbolType; the Image field contains an Ebiten image
+// ready to be drawn in the UI.
 type Symbol struct {
-	Type  SymbolType
+	Type  game.SymbolType
 	Image *ebiten.Image
 }
 
@@ -46,7 +31,7 @@ type Symbol struct {
 //
 // The symbols are currently drawn with a white stroke. Color and styling could
 // later be parameterized to support theming.
-func generateSymbol(symbolType SymbolType) *ebiten.Image {
+func generateSymbol(symbolType game.SymbolType) *ebiten.Image {
 	dc := gg.NewContext(symbolImageSizePx, symbolImageSizePx)
 	dc.SetColor(color.White)
 	dc.SetLineWidth(symbolLineThicknessPx)
@@ -55,7 +40,7 @@ func generateSymbol(symbolType SymbolType) *ebiten.Image {
 	max := float64(symbolImageSizePx)
 
 	switch symbolType {
-	case CrossSymbol:
+	case game.CrossSymbol:
 		// Diagonal from top-left to bottom-right
 		dc.DrawLine(halfStroke, halfStroke, max-halfStroke, max-halfStroke)
 		dc.Stroke()
@@ -63,19 +48,19 @@ func generateSymbol(symbolType SymbolType) *ebiten.Image {
 		dc.DrawLine(halfStroke, max-halfStroke, max-halfStroke, halfStroke)
 		dc.Stroke()
 
-	case CircleSymbol:
+	case game.CircleSymbol:
 		radius := (max / 2) - halfStroke
 		dc.DrawCircle(max/2, max/2, radius)
 		dc.Stroke()
 
-	case TriangleSymbol:
+	case game.TriangleSymbol:
 		dc.MoveTo(max/2, halfStroke)
 		dc.LineTo(max-halfStroke, max-halfStroke)
 		dc.LineTo(halfStroke, max-halfStroke)
 		dc.ClosePath()
 		dc.Stroke()
 
-	case SquareSymbol:
+	case game.SquareSymbol:
 		// Draw a square inset by half the stroke on all sides.
 		side := max - float64(symbolLineThicknessPx)
 		dc.DrawRectangle(halfStroke, halfStroke, side, side)
@@ -88,7 +73,7 @@ func generateSymbol(symbolType SymbolType) *ebiten.Image {
 // NewSymbol creates a new Symbol instance by generating its image procedurally.
 //
 // The returned Symbol is ready to be drawn by the UI.
-func NewSymbol(symbolType SymbolType) *Symbol {
+func NewSymbol(symbolType game.SymbolType) *Symbol {
 	return &Symbol{
 		Type:  symbolType,
 		Image: generateSymbol(symbolType),
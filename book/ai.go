@@ -0,0 +1,115 @@
+/**
+ ******************************************************************************
+ * @file            : ai.go
+ * @brief           : GoTicTacToe - Opening book AI
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements AI, the lookup layer in front of any AI model: book
+ * moves are played instantly during the opening, and the wrapped model
+ * takes over once the position leaves the book.
+ ******************************************************************************
+ */
+
+package book
+
+import (
+	"GoTicTacToe/ai_models"
+	"GoTicTacToe/game"
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// AI is an AI model that plays from a book in the opening and asks Model
+// otherwise.
+type AI struct {
+	Book  *Book             // Opening book (nil to always use Model)
+	Model ai_models.AIModel // Model used out of book (MinimaxAI if nil)
+
+	// MaxPlies stops using the book once the board holds this many tokens
+	// (0 = use the book as long as it knows the position).
+	MaxPlies int
+
+	Seed int64 // Random seed for book moves (0 = seeded from the clock)
+}
+
+// NextMove returns a book move if the position is in the book, or the move
+// of the model otherwise.
+func (ai AI) NextMove(board *game.Board, me *game.Player, players []*game.Player) (int, int) {
+	if m, ok := ai.bookMove(board, me, players); ok {
+		return m.X, m.Y
+	}
+	return ai.model().NextMove(board, me, players)
+}
+
+// NextMoveWithBudget behaves like NextMove, giving budget to the model when
+// it supports a time budget.
+func (ai AI) NextMoveWithBudget(board *game.Board, me *game.Player, players []*game.Player, budget time.Duration) (int, int) {
+	if m, ok := ai.bookMove(board, me, players); ok {
+		return m.X, m.Y
+	}
+	if budgeted, ok := ai.model().(ai_models.BudgetedAIModel); ok {
+		return budgeted.NextMoveWithBudget(board, me, players, budget)
+	}
+	return ai.model().NextMove(board, me, players)
+}
+
+// Search implements ai_models.Engine. Book moves are returned at once, with
+// an empty search report; other positions are searched by the model.
+func (ai AI) Search(ctx context.Context, board *game.Board, me *game.Player, players []*game.Player, limits ai_models.Limits) (game.Move, ai_models.Info, error) {
+	if err := ctx.Err(); err != nil {
+		return game.Move{X: -1, Y: -1}, ai_models.Info{}, err
+	}
+	if m, ok := ai.bookMove(board, me, players); ok {
+		return m, ai_models.Info{PV: []game.Move{m}}, nil
+	}
+	return ai_models.Adapt(ai.model()).Search(ctx, board, me, players, limits)
+}
+
+// String returns the name of the model followed by "+ book", as shown in
+// game records.
+func (ai AI) String() string {
+	model := ai.model()
+	name, ok := model.(fmt.Stringer)
+	if ok {
+		return name.String() + " + book"
+	}
+	typeName := fmt.Sprintf("%T", model)
+	return typeName[strings.LastIndex(typeName, ".")+1:] + " + book"
+}
+
+// bookMove returns a move of me drawn from the book, if the book covers the
+// position.
+func (ai AI) bookMove(board *game.Board, me *game.Player, players []*game.Player) (game.Move, bool) {
+	if ai.Book == nil || len(players) != ai.Book.Players {
+		return game.Move{}, false
+	}
+	if ai.MaxPlies > 0 && board.Width*board.Height-board.EmptyCount() >= ai.MaxPlies {
+		return game.Move{}, false
+	}
+
+	seed := ai.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	m, ok := ai.Book.Pick(board, me.ID, rand.New(rand.NewSource(seed)))
+	if !ok || !isFree(board, m) {
+		return game.Move{}, false
+	}
+	return m, true
+}
+
+// model returns the model used out of book.
+func (ai AI) model() ai_models.AIModel {
+	if ai.Model == nil {
+		return ai_models.MinimaxAI{}
+	}
+	return ai.Model
+}
@@ -0,0 +1,189 @@
+/**
+ ******************************************************************************
+ * @file            : book.go
+ * @brief           : GoTicTacToe - Opening book definition
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements Book, a map from positions to weighted candidate
+ * moves. Positions are stored in canonical form (see game.Board.Canonical)
+ * with tokens relabeled from the side to move, so a single entry serves
+ * every symmetric variant of a position, whoever started the round.
+ *
+ * Like solver tables, books assume that turns follow player IDs (0, 1, ...
+ * wrapping around).
+ ******************************************************************************
+ */
+
+// Package book implements opening books: weighted candidate moves for the
+// first plies of a board configuration, their file format, their offline
+// construction and an AI model playing from them.
+package book
+
+import (
+	"GoTicTacToe/game"
+	"math/rand"
+)
+
+// Candidate is a book move with its weight: moves are picked with a
+// probability proportional to their weight.
+type Candidate struct {
+	Move   game.Move
+	Weight int
+}
+
+// Book maps positions to candidate moves for the side to move.
+type Book struct {
+	Players int // Number of players the book was built for
+
+	entries map[string][]Candidate // Candidates on the canonical board, by key
+}
+
+// New returns an empty book for games with the given number of players.
+func New(players int) *Book {
+	return &Book{Players: players, entries: map[string][]Candidate{}}
+}
+
+// Len returns the number of positions in the book.
+func (b *Book) Len() int {
+	return len(b.entries)
+}
+
+// Add adds weight to the candidate move m of the player whose ID is toMove
+// on board, creating the candidate if needed. Moves equivalent under the
+// symmetries of the position share a single candidate.
+//
+// It returns false if the position cannot be stored (player IDs outside the
+// book, occupied cell or weight not positive).
+func (b *Book) Add(board *game.Board, toMove int, m game.Move, weight int) bool {
+	if weight <= 0 || !isFree(board, m) {
+		return false
+	}
+	key, canonical, sym, ok := b.key(board, toMove)
+	if !ok {
+		return false
+	}
+
+	m = normalize(canonical, sym.Apply(m, board.Width, board.Height))
+	candidates := b.entries[key]
+	for i := range candidates {
+		if candidates[i].Move == m {
+			candidates[i].Weight += weight
+			return true
+		}
+	}
+	b.entries[key] = append(candidates, Candidate{Move: m, Weight: weight})
+	return true
+}
+
+// Lookup returns the candidate moves of the player whose ID is toMove on
+// board, in board coordinates. It returns false if the position is not in
+// the book.
+func (b *Book) Lookup(board *game.Board, toMove int) ([]Candidate, bool) {
+	key, _, sym, ok := b.key(board, toMove)
+	if !ok {
+		return nil, false
+	}
+	stored, ok := b.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	inverse := sym.Inverse()
+	candidates := make([]Candidate, len(stored))
+	for i, c := range stored {
+		candidates[i] = Candidate{Move: inverse.Apply(c.Move, board.Width, board.Height), Weight: c.Weight}
+	}
+	return candidates, true
+}
+
+// Pick returns a book move of the player whose ID is toMove on board, drawn
+// at random according to the weights. When the position is symmetric, the
+// move is also drawn among its equivalent cells.
+//
+// It returns false if the position is not in the book.
+func (b *Book) Pick(board *game.Board, toMove int, rng *rand.Rand) (game.Move, bool) {
+	key, canonical, sym, ok := b.key(board, toMove)
+	if !ok {
+		return game.Move{}, false
+	}
+	candidates := b.entries[key]
+
+	total := 0
+	for _, c := range candidates {
+		total += c.Weight
+	}
+	if total <= 0 {
+		return game.Move{}, false
+	}
+
+	r := rng.Intn(total)
+	var m game.Move
+	for _, c := range candidates {
+		if r < c.Weight {
+			m = c.Move
+			break
+		}
+		r -= c.Weight
+	}
+
+	stabilizer := canonical.Stabilizer()
+	m = stabilizer[rng.Intn(len(stabilizer))].Apply(m, canonical.Width, canonical.Height)
+	return sym.Inverse().Apply(m, board.Width, board.Height), true
+}
+
+// key returns the key of the position of board with toMove to play, its
+// canonical board and the symmetry mapping board onto it.
+//
+// Tokens are relabeled by turn order from the side to move: the side to move
+// becomes player 0, the next player 1, and so on.
+func (b *Book) key(board *game.Board, toMove int) (string, *game.Board, game.Symmetry, bool) {
+	n := b.Players
+	if toMove < 0 || toMove >= n {
+		return "", nil, game.IDENTITY, false
+	}
+
+	labels := make([]*game.Player, n)
+	for i := range labels {
+		labels[i] = &game.Player{ID: i}
+	}
+
+	relabeled := game.NewBoard(board.Width, board.Height, board.WinLength())
+	for x := 0; x < board.Width; x++ {
+		for y := 0; y < board.Height; y++ {
+			p := board.Cells[x][y]
+			if p == nil {
+				continue
+			}
+			if p.ID < 0 || p.ID >= n {
+				return "", nil, game.IDENTITY, false
+			}
+			relabeled.Play(labels[(p.ID-toMove+n)%n], x, y)
+		}
+	}
+
+	canonical, sym := relabeled.Canonical()
+	return canonical.Key(), canonical, sym, true
+}
+
+// normalize returns the representative of m among the moves equivalent to
+// it on the canonical board: the first one in column-major order.
+func normalize(canonical *game.Board, m game.Move) game.Move {
+	best := m
+	for _, s := range canonical.Stabilizer() {
+		c := s.Apply(m, canonical.Width, canonical.Height)
+		if c.X < best.X || (c.X == best.X && c.Y < best.Y) {
+			best = c
+		}
+	}
+	return best
+}
+
+// isFree reports whether m is an empty cell of board.
+func isFree(board *game.Board, m game.Move) bool {
+	return m.X >= 0 && m.Y >= 0 && m.X < board.Width && m.Y < board.Height && board.Cells[m.X][m.Y] == nil
+}
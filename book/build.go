@@ -0,0 +1,265 @@
+/**
+ ******************************************************************************
+ * @file            : build.go
+ * @brief           : GoTicTacToe - Opening book builder
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the book builder. Starting from the empty board, it
+ * scores every distinct move of the side to move, either with a deep search
+ * of the reply or exactly from a solver table, keeps the best moves within
+ * a score margin and follows them until the book depth is reached.
+ *
+ * Building is slow (a full search per candidate move) and meant to be run
+ * offline, see cmd/bookgen.
+ ******************************************************************************
+ */
+
+package book
+
+import (
+	"GoTicTacToe/ai_models"
+	"GoTicTacToe/game"
+	"GoTicTacToe/solver"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Builder defaults.
+const (
+	defaultPlies    = 4
+	defaultMaxMoves = 3
+	defaultMargin   = 40
+	defaultBudget   = time.Second
+
+	// winScore is the score of a move winning at once. Wins found by a solver
+	// table are scored below it by their distance.
+	winScore = 1 << 30
+)
+
+// BuildConfig describes the book to build. Zero fields use the defaults.
+type BuildConfig struct {
+	Width  int // Number of columns
+	Height int // Number of rows
+	ToWin  int // Required aligned symbols to win
+
+	Plies    int // Positions with fewer tokens get book moves (default 4)
+	MaxMoves int // Candidates kept per position (default 3)
+
+	// Margin is the largest score gap with the best move for a candidate to
+	// be kept (default 40, negative keeps the best score only). Candidate
+	// weights decrease linearly from Margin+1 for the best move to 1 at the
+	// margin.
+	Margin int
+
+	// Engine scores the moves by searching the reply (MinimaxAI if nil),
+	// with Budget per move (default 1s).
+	Engine ai_models.Engine
+	Budget time.Duration
+
+	// Table, when set, scores the moves exactly instead of Engine. It must
+	// be a two-player table of the same configuration.
+	Table *solver.Table
+
+	// Progress, when set, is called after each position is added.
+	Progress func(positions int)
+}
+
+// scoredMove is a move with its score for the side to move.
+type scoredMove struct {
+	move  game.Move
+	score int
+}
+
+// builder holds the state of a Build call.
+type builder struct {
+	cfg     BuildConfig
+	ctx     context.Context
+	book    *Book
+	players []*game.Player
+	seen    map[string]bool // Keys of the positions already expanded
+}
+
+// Build builds a two-player book for cfg.
+//
+// If ctx is done before the end, it returns the book built so far along
+// with the context error.
+func Build(ctx context.Context, cfg BuildConfig) (*Book, error) {
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.ToWin < 1 {
+		return nil, fmt.Errorf("book: invalid board %dx%d/%d", cfg.Width, cfg.Height, cfg.ToWin)
+	}
+	if cfg.Plies == 0 {
+		cfg.Plies = defaultPlies
+	}
+	if cfg.MaxMoves <= 0 {
+		cfg.MaxMoves = defaultMaxMoves
+	}
+	if cfg.Margin == 0 {
+		cfg.Margin = defaultMargin
+	}
+	cfg.Margin = max(cfg.Margin, 0)
+	if cfg.Budget == 0 {
+		cfg.Budget = defaultBudget
+	}
+	if cfg.Engine == nil {
+		cfg.Engine = ai_models.MinimaxAI{}
+	}
+	if cfg.Table != nil && cfg.Table.Config.Players != 2 {
+		return nil, fmt.Errorf("book: table for %d players, want 2", cfg.Table.Config.Players)
+	}
+
+	g := game.NewGameWithConfig(cfg.Width, cfg.Height, cfg.ToWin, nil)
+	bd := &builder{
+		cfg:     cfg,
+		ctx:     ctx,
+		book:    New(len(g.Players)),
+		players: g.Players,
+		seen:    map[string]bool{},
+	}
+	err := bd.expand(g.Board, g.Players[0], 0)
+	return bd.book, err
+}
+
+// expand adds the book moves of position board, with me to play at ply,
+// then expands the positions they lead to.
+func (bd *builder) expand(board *game.Board, me *game.Player, ply int) error {
+	if ply >= bd.cfg.Plies {
+		return nil
+	}
+	key, _, _, _ := bd.book.key(board, me.ID)
+	if bd.seen[key] {
+		return nil
+	}
+	bd.seen[key] = true
+
+	scored, err := bd.score(board, me)
+	if err != nil || len(scored) == 0 {
+		return err
+	}
+
+	best := scored[0].score
+	var kept []game.Move
+	for _, sm := range scored {
+		gap := best - sm.score
+		if len(kept) == bd.cfg.MaxMoves || gap > bd.cfg.Margin {
+			break
+		}
+		bd.book.Add(board, me.ID, sm.move, bd.cfg.Margin-gap+1)
+		kept = append(kept, sm.move)
+	}
+	if bd.cfg.Progress != nil {
+		bd.cfg.Progress(bd.book.Len())
+	}
+
+	next := me.Next(bd.players)
+	for _, m := range kept {
+		if board.WinsWith(me, m.X, m.Y) {
+			continue
+		}
+		board.Play(me, m.X, m.Y)
+		err := bd.expand(board, next, ply+1)
+		board.Undo(m.X, m.Y)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// score returns the distinct moves of me worth considering, best first.
+func (bd *builder) score(board *game.Board, me *game.Player) ([]scoredMove, error) {
+	next := me.Next(bd.players)
+
+	var scored []scoredMove
+	for _, m := range candidates(board) {
+		sm := scoredMove{move: m}
+		if board.WinsWith(me, m.X, m.Y) {
+			sm.score = winScore
+			scored = append(scored, sm)
+			continue
+		}
+
+		board.Play(me, m.X, m.Y)
+		var err error
+		sm.score, err = bd.replyScore(board, next)
+		board.Undo(m.X, m.Y)
+		if err != nil {
+			return nil, err
+		}
+		scored = append(scored, sm)
+	}
+
+	// Among equal scores (typically all wins or all draws), central moves
+	// come first: they keep more options open.
+	slices.SortStableFunc(scored, func(a, b scoredMove) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		return centerDistance(board, a.move) - centerDistance(board, b.move)
+	})
+	return scored, nil
+}
+
+// centerDistance returns the squared distance of m to the center of the
+// board, doubled on each axis to stay integral.
+func centerDistance(board *game.Board, m game.Move) int {
+	dx := 2*m.X - (board.Width - 1)
+	dy := 2*m.Y - (board.Height - 1)
+	return dx*dx + dy*dy
+}
+
+// replyScore returns the score of the position for the player who just
+// moved, next being the side to move.
+func (bd *builder) replyScore(board *game.Board, next *game.Player) (int, error) {
+	if err := bd.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if board.EmptyCount() == 0 {
+		return 0, nil
+	}
+
+	if bd.cfg.Table != nil {
+		e, ok := bd.cfg.Table.Lookup(board, next.ID)
+		if !ok {
+			return 0, fmt.Errorf("book: position %s missing from the table", board.Encode())
+		}
+		distance := int(e.Distance) + 1
+		switch e.Outcome {
+		case solver.OutcomeDraw:
+			return 0, nil
+		case solver.OutcomeWin:
+			return -winScore + distance, nil
+		default:
+			return winScore - distance, nil
+		}
+	}
+
+	_, info, err := bd.cfg.Engine.Search(bd.ctx, board, next, bd.players, ai_models.Limits{Budget: bd.cfg.Budget})
+	if err != nil {
+		return 0, err
+	}
+	return -info.Score, nil
+}
+
+// candidates returns the distinct moves (see game.Board.UniqueMoves) next
+// to a token, or every distinct move on an empty board.
+func candidates(board *game.Board) []game.Move {
+	moves := board.UniqueMoves()
+	if board.EmptyCount() == board.Width*board.Height {
+		return moves
+	}
+
+	var near []game.Move
+	for _, m := range moves {
+		if board.HasNeighbour(m.X, m.Y) {
+			near = append(near, m)
+		}
+	}
+	return near
+}
@@ -0,0 +1,210 @@
+/**
+ ******************************************************************************
+ * @file            : file.go
+ * @brief           : GoTicTacToe - Opening book file format
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the book file format. The first line gives the
+ * number of players; every other line holds a position key (see
+ * game.Board.Key, on the canonical relabeled board) followed by its
+ * candidate moves and their weights. Blank lines and lines starting with
+ * '#' are ignored:
+ *
+ *   # 3x3/3 opening book
+ *   players 2
+ *   3x3/3:......... a1=3 b2=2
+ *   3x3/3:0........ b2=1
+ ******************************************************************************
+ */
+
+package book
+
+import (
+	"GoTicTacToe/game"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Book file syntax.
+const (
+	playersDirective = "players"
+	commentPrefix    = "#"
+	weightSeparator  = "="
+
+	// keyCellsSeparator ends the "WxH/K" header of a position key.
+	keyCellsSeparator = ":"
+
+	// keyEmptyCell is the character of an empty cell in a position key.
+	keyEmptyCell = '.'
+)
+
+// ErrInvalidBook is wrapped by every error reported when reading a book.
+var ErrInvalidBook = errors.New("book: invalid book file")
+
+// Read parses the book contained in r.
+//
+// Errors report the faulty line.
+func Read(r io.Reader) (*Book, error) {
+	var b *Book
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], commentPrefix) {
+			continue
+		}
+
+		var err error
+		if b == nil {
+			b, err = readHeader(fields)
+		} else {
+			err = b.readEntry(fields)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidBook, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, fmt.Errorf("%w: missing %q line", ErrInvalidBook, playersDirective)
+	}
+	return b, nil
+}
+
+// ReadFile parses the book stored in the file at path.
+func ReadFile(path string) (*Book, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Write writes the book to w, positions sorted by key and candidates by
+// decreasing weight.
+func (b *Book) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s %d\n", playersDirective, b.Players)
+
+	keys := make([]string, 0, len(b.entries))
+	for key := range b.entries {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		candidates := slices.Clone(b.entries[key])
+		slices.SortStableFunc(candidates, func(a, c Candidate) int {
+			return c.Weight - a.Weight
+		})
+
+		bw.WriteString(key)
+		for _, c := range candidates {
+			fmt.Fprintf(bw, " %s%s%d", c.Move, weightSeparator, c.Weight)
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// WriteFile writes the book to the file at path, replacing it.
+func (b *Book) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := b.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readHeader parses the "players N" line.
+func readHeader(fields []string) (*Book, error) {
+	if len(fields) != 2 || fields[0] != playersDirective {
+		return nil, fmt.Errorf("expected %q followed by the number of players", playersDirective)
+	}
+	players, err := strconv.Atoi(fields[1])
+	if err != nil || players < 1 {
+		return nil, fmt.Errorf("invalid number of players %q", fields[1])
+	}
+	return New(players), nil
+}
+
+// readEntry parses a position line and adds its candidates to the book.
+func (b *Book) readEntry(fields []string) error {
+	key := fields[0]
+	if _, dup := b.entries[key]; dup {
+		return fmt.Errorf("duplicate position %q", key)
+	}
+	width, height, cells, err := parseKey(key, b.Players)
+	if err != nil {
+		return err
+	}
+	if len(fields) < 2 {
+		return fmt.Errorf("position %q has no move", key)
+	}
+
+	candidates := make([]Candidate, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		move, weight, ok := strings.Cut(field, weightSeparator)
+		if !ok {
+			return fmt.Errorf("expected \"move%sweight\", got %q", weightSeparator, field)
+		}
+		m, err := game.ParseMove(move)
+		if err != nil {
+			return err
+		}
+		if m.X >= width || m.Y >= height || cells[m.Y*width+m.X] != keyEmptyCell {
+			return fmt.Errorf("move %s is not an empty cell of %q", m, key)
+		}
+		w, err := strconv.Atoi(weight)
+		if err != nil || w < 1 {
+			return fmt.Errorf("invalid weight %q for move %s", weight, m)
+		}
+		candidates = append(candidates, Candidate{Move: m, Weight: w})
+	}
+	b.entries[key] = candidates
+	return nil
+}
+
+// parseKey checks a position key and returns the board dimensions and the
+// cells (row by row) it encodes.
+func parseKey(key string, players int) (int, int, string, error) {
+	header, cells, ok := strings.Cut(key, keyCellsSeparator)
+	if !ok {
+		return 0, 0, "", fmt.Errorf("invalid position key %q", key)
+	}
+
+	var width, height, toWin int
+	if _, err := fmt.Sscanf(header, "%dx%d/%d", &width, &height, &toWin); err != nil ||
+		width < 1 || height < 1 || toWin < 1 || len(cells) != width*height {
+		return 0, 0, "", fmt.Errorf("invalid position key %q", key)
+	}
+	for _, c := range []byte(cells) {
+		if c == keyEmptyCell {
+			continue
+		}
+		if id, err := strconv.ParseInt(string(c), 36, 0); err != nil || int(id) >= players {
+			return 0, 0, "", fmt.Errorf("invalid cell %q in position key %q", c, key)
+		}
+	}
+	return width, height, cells, nil
+}
@@ -0,0 +1,87 @@
+/**
+ ******************************************************************************
+ * @file            : main.go
+ * @brief           : GoTicTacToe - Opening book builder command
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains the command line entry point of the book builder. It
+ * scores the opening moves with deep searches, or exactly with a table
+ * saved by cmd/solve, and writes the book to a file or to the standard
+ * output:
+ *
+ *   go run ./cmd/bookgen -width 6 -height 6 -towin 4 -plies 4 -out assets/static/books/6x6-4.book
+ *   go run ./cmd/bookgen -width 4 -height 4 -towin 3 -table 4x4-3.tb -plies 6
+ ******************************************************************************
+ */
+
+// Package main implements the bookgen command, which builds an opening book
+// for a board configuration.
+package main
+
+import (
+	"GoTicTacToe/book"
+	"GoTicTacToe/solver"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	width := flag.Int("width", 6, "number of columns")
+	height := flag.Int("height", 6, "number of rows")
+	toWin := flag.Int("towin", 4, "aligned symbols required to win")
+	plies := flag.Int("plies", 0, "book depth in plies (0 = default)")
+	moves := flag.Int("moves", 0, "candidate moves kept per position (0 = default)")
+	margin := flag.Int("margin", 0, "largest score gap with the best move (0 = default, negative = best score only)")
+	budget := flag.Duration("budget", 0, "search time per candidate move (0 = default)")
+	tablePath := flag.String("table", "", "score moves exactly with this solver table")
+	out := flag.String("out", "", "write the book to this file instead of the standard output")
+	flag.Parse()
+
+	cfg := book.BuildConfig{
+		Width: *width, Height: *height, ToWin: *toWin,
+		Plies: *plies, MaxMoves: *moves, Margin: *margin, Budget: *budget,
+		Progress: func(positions int) {
+			log.Printf("%d positions", positions)
+		},
+	}
+	if *tablePath != "" {
+		table, err := solver.ReadFile(*tablePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Table = table
+	}
+
+	// Ctrl+C stops the build but still writes the positions found so far.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	start := time.Now()
+	b, err := book.Build(ctx, cfg)
+	if err != nil {
+		if b == nil {
+			log.Fatal(err)
+		}
+		log.Print(err)
+	}
+	log.Printf("%d positions (%s)", b.Len(), time.Since(start).Round(time.Millisecond))
+
+	if *out != "" {
+		if err := b.WriteFile(*out); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := b.Write(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
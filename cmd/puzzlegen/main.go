@@ -0,0 +1,83 @@
+/**
+ ******************************************************************************
+ * @file            : main.go
+ * @brief           : GoTicTacToe - Puzzle generator command
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains the command line entry point of the puzzle generator.
+ * It writes the puzzles in the puzzle file format, to a file or to the
+ * standard output:
+ *
+ *   go run ./cmd/puzzlegen -width 5 -height 5 -towin 4 -max 3 -count 10 -out puzzles.txt
+ *   go run ./cmd/puzzlegen -width 4 -height 4 -towin 3 -daily 2026-01-09
+ ******************************************************************************
+ */
+
+// Package main implements the puzzlegen command, which generates forced-win
+// puzzles for a board configuration.
+package main
+
+import (
+	"GoTicTacToe/puzzle"
+	"flag"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	width := flag.Int("width", 3, "number of columns")
+	height := flag.Int("height", 3, "number of rows")
+	toWin := flag.Int("towin", 3, "aligned symbols required to win")
+	minWinIn := flag.Int("min", 2, "fewest moves to win")
+	maxWinIn := flag.Int("max", 3, "most moves to win")
+	count := flag.Int("count", 5, "number of puzzles to generate")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	games := flag.Int("games", 0, "games to try before giving up (0 = default)")
+	daily := flag.String("daily", "", "generate the daily puzzle of this date (YYYY-MM-DD, \"today\" for today)")
+	out := flag.String("out", "", "write the puzzles to this file instead of the standard output")
+	flag.Parse()
+
+	cfg := puzzle.GeneratorConfig{
+		Width: *width, Height: *height, ToWin: *toWin,
+		MinWinIn: *minWinIn, MaxWinIn: *maxWinIn,
+		Count: *count, Seed: *seed, MaxGames: *games,
+	}
+
+	var puzzles []*puzzle.Puzzle
+	if *daily != "" {
+		date := time.Now()
+		if *daily != "today" {
+			var err error
+			if date, err = time.Parse(time.DateOnly, *daily); err != nil {
+				log.Fatal(err)
+			}
+		}
+		p, err := puzzle.Daily(cfg, date)
+		if err != nil {
+			log.Fatal(err)
+		}
+		puzzles = append(puzzles, p)
+	} else {
+		var err error
+		if puzzles, err = puzzle.Generate(cfg); err != nil {
+			// Keep what was found, but tell why there is less.
+			log.Print(err)
+		}
+	}
+
+	if *out != "" {
+		if err := puzzle.WriteFile(*out, puzzles...); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := puzzle.Write(os.Stdout, puzzles...); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,71 @@
+/**
+ ******************************************************************************
+ * @file            : main.go
+ * @brief           : GoTicTacToe - Solver command
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file contains the command line entry point of the solver. It solves
+ * the requested configuration, prints the result under perfect play and can
+ * save the table for the tablebase AI:
+ *
+ *   go run ./cmd/solve -width 4 -height 4 -towin 3 -out 4x4-3.tb
+ ******************************************************************************
+ */
+
+// Package main implements the solve command, which prints the theoretical
+// result of a board configuration.
+package main
+
+import (
+	"GoTicTacToe/solver"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	width := flag.Int("width", 3, "number of columns")
+	height := flag.Int("height", 3, "number of rows")
+	toWin := flag.Int("towin", 3, "aligned symbols required to win")
+	players := flag.Int("players", 2, "number of players")
+	out := flag.String("out", "", "write the solved table to this file")
+	prune := flag.Bool("prune", false, "stop at the first winning move (smaller table, exact result)")
+	maxPositions := flag.Int("max", 0, "abort after storing this many positions (0 = no limit)")
+	flag.Parse()
+
+	cfg := solver.Config{Width: *width, Height: *height, ToWin: *toWin, Players: *players}
+	start := time.Now()
+
+	table, err := solver.Solve(cfg, solver.Options{MaxPositions: *maxPositions, Prune: *prune})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	root := table.Root()
+	result := "draw"
+	if root.Outcome != solver.OutcomeDraw {
+		result = fmt.Sprintf("win for player %d", int(root.Outcome)+1)
+	}
+	distance := fmt.Sprintf("%d", root.Distance)
+	if table.Pruned && root.Outcome == solver.OutcomeWin {
+		// Pruned searches stop at the first win found, not the fastest one.
+		distance = "at most " + distance
+	}
+
+	fmt.Printf("Configuration: %s\n", cfg)
+	fmt.Printf("Result:        %s in %s plies\n", result, distance)
+	fmt.Printf("Positions:     %d (%s)\n", table.Len(), time.Since(start).Round(time.Millisecond))
+
+	if *out != "" {
+		if err := table.WriteFile(*out); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Table saved:   %s\n", *out)
+	}
+}
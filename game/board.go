@@ -1,16 +1,23 @@
 package game
 
+import "slices"
+
 // Board represents the game grid and contains player tokens.
 //
 // Cells is a Width x Height matrix of *Player (accessed as Cells[x][y]).
 // Width is the number of columns.
 // Height is the number of rows.
 // ToWin defines how many aligned symbols are required to win (variant support).
+//
+// Cells should be modified through Play, Undo and Clear so the Zobrist hash
+// stays in sync (see Hash). Call RecomputeHash after editing Cells directly.
 type Board struct {
 	Cells  [][]*Player
 	Width  int // Number of columns
 	Height int // Number of rows
 	ToWin  int // Required aligned symbols to win
+
+	hash uint64 // Incremental Zobrist hash of the position
 }
 
 // Direction represents a 2D step (dx, dy) used for line scanning.
@@ -67,6 +74,23 @@ func (b *Board) Play(p *Player, x, y int) bool {
 	}
 
 	b.Cells[x][y] = p
+	b.hash ^= zobristKey(x, y, p.ID)
+	return true
+}
+
+// Undo removes the token at grid coordinates (x, y), reverting a previous Play.
+// Returns false if the coordinates are out of bounds or the cell is empty.
+func (b *Board) Undo(x, y int) bool {
+	if !b.inBounds(x, y) {
+		return false
+	}
+	p := b.Cells[x][y]
+	if p == nil {
+		return false
+	}
+
+	b.Cells[x][y] = nil
+	b.hash ^= zobristKey(x, y, p.ID)
 	return true
 }
 
@@ -87,29 +111,91 @@ func (b *Board) CheckWin() *Player {
 			}
 
 			for _, dir := range winDirections {
-				count := initialStreakCount
+				if b.lineFrom(x, y, dir, target) {
+					return start
+				}
+			}
+		}
+	}
 
-				for step := firstStep; step < target; step++ {
-					nx := x + dir.DX*step
-					ny := y + dir.DY*step
+	return nil
+}
 
-					if !b.inBounds(nx, ny) {
-						break
-					}
-					if b.Cells[nx][ny] != start {
-						break
-					}
-					count++
-				}
+// winners returns every player owning a complete line, in order of first
+// appearance. A position reached by legal play has at most one.
+func (b *Board) winners() []*Player {
+	target := b.effectiveToWin()
 
-				if count == target {
-					return start
+	var owners []*Player
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			start := b.Cells[x][y]
+			if start == nil || slices.Contains(owners, start) {
+				continue
+			}
+
+			for _, dir := range winDirections {
+				if b.lineFrom(x, y, dir, target) {
+					owners = append(owners, start)
+					break
 				}
 			}
 		}
 	}
 
-	return nil
+	return owners
+}
+
+// lineFrom reports whether the target cells starting at the non-empty cell
+// (x, y) in direction dir all hold the same token.
+func (b *Board) lineFrom(x, y int, dir Direction, target int) bool {
+	start := b.Cells[x][y]
+	count := initialStreakCount
+
+	for step := firstStep; step < target; step++ {
+		nx := x + dir.DX*step
+		ny := y + dir.DY*step
+
+		if !b.inBounds(nx, ny) {
+			break
+		}
+		if b.Cells[nx][ny] != start {
+			break
+		}
+		count++
+	}
+
+	return count == target
+}
+
+// WinsWith reports whether player p playing at the empty cell (x, y) would
+// complete a line of WinLength symbols through it.
+//
+// Only the four lines through (x, y) are scanned, which makes it much cheaper
+// than playing the move and calling CheckWin. The board is not modified.
+func (b *Board) WinsWith(p *Player, x, y int) bool {
+	if !b.inBounds(x, y) || b.Cells[x][y] != nil {
+		return false
+	}
+	target := b.effectiveToWin()
+
+	for _, dir := range winDirections {
+		count := initialStreakCount
+		for _, sign := range [...]int{1, -1} {
+			for step := firstStep; count < target; step++ {
+				nx := x + sign*dir.DX*step
+				ny := y + sign*dir.DY*step
+				if !b.inBounds(nx, ny) || b.Cells[nx][ny] != p {
+					break
+				}
+				count++
+			}
+		}
+		if count >= target {
+			return true
+		}
+	}
+	return false
 }
 
 // CheckDraw returns true if the board is full (no empty cell remains).
@@ -126,6 +212,60 @@ func (b *Board) CheckDraw() bool {
 	return true
 }
 
+// CanComplete reports whether player p could still align ToWin symbols by
+// playing at most maxMoves more tokens.
+//
+// It looks for a window of ToWin consecutive cells (in any direction) that
+// contains no opponent token and at most maxMoves empty cells. Opponent
+// replies are not simulated, so a true result does not mean p can force a win;
+// a false result, however, means p can no longer win at all.
+func (b *Board) CanComplete(p *Player, maxMoves int) bool {
+	target := b.effectiveToWin()
+
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			for _, dir := range winDirections {
+				endX := x + dir.DX*(target-1)
+				endY := y + dir.DY*(target-1)
+				if !b.inBounds(endX, endY) {
+					continue
+				}
+
+				empty := 0
+				blocked := false
+				for step := 0; step < target; step++ {
+					c := b.Cells[x+dir.DX*step][y+dir.DY*step]
+					if c == nil {
+						empty++
+					} else if c != p {
+						blocked = true
+						break
+					}
+				}
+
+				if !blocked && empty <= maxMoves {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// EmptyCount returns the number of empty cells on the board.
+func (b *Board) EmptyCount() int {
+	count := 0
+	for x := range b.Cells {
+		for y := range b.Cells[x] {
+			if b.Cells[x][y] == nil {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 // Clear resets all cells to nil (empty board).
 func (b *Board) Clear() {
 	for x := range b.Cells {
@@ -133,6 +273,7 @@ func (b *Board) Clear() {
 			b.Cells[x][y] = nil
 		}
 	}
+	b.hash = 0
 }
 
 // AvailableMoves returns all empty cell positions on the board.
@@ -148,6 +289,20 @@ func (b *Board) AvailableMoves() []Move {
 	return moves
 }
 
+// HasNeighbour reports whether one of the 8 cells around (x, y) holds a
+// token. Searches use it to skip moves far from the action.
+func (b *Board) HasNeighbour(x, y int) bool {
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			nx, ny := x+dx, y+dy
+			if (dx != 0 || dy != 0) && b.inBounds(nx, ny) && b.Cells[nx][ny] != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Clone creates a deep copy of the board.
 //
 // Note: Players are referenced (not cloned), which is intended: players are
@@ -159,9 +314,16 @@ func (b *Board) Clone() *Board {
 			clone.Cells[x][y] = b.Cells[x][y]
 		}
 	}
+	clone.hash = b.hash
 	return clone
 }
 
+// WinLength returns the number of aligned symbols actually required to win:
+// ToWin, clamped to the smallest board dimension when it is not usable.
+func (b *Board) WinLength() int {
+	return b.effectiveToWin()
+}
+
 // inBounds returns true if (x, y) is within the board limits.
 func (b *Board) inBounds(x, y int) bool {
 	return x >= 0 && y >= 0 && x < b.Width && y < b.Height
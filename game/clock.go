@@ -0,0 +1,299 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TimeSource provides the current time to clocks.
+//
+// The game never calls time.Now directly so that clocks can be driven by a
+// fake source (see ManualTime) and tested deterministically.
+type TimeSource interface {
+	Now() time.Time
+}
+
+// SystemTime is the TimeSource backed by the system wall clock.
+type SystemTime struct{}
+
+// Now returns the current system time.
+func (SystemTime) Now() time.Time {
+	return time.Now()
+}
+
+// ManualTime is a TimeSource that only moves when Advance is called.
+type ManualTime struct {
+	Current time.Time
+}
+
+// Now returns the current manual time.
+func (m *ManualTime) Now() time.Time {
+	return m.Current
+}
+
+// Advance moves the manual time forward by d.
+func (m *ManualTime) Advance(d time.Duration) {
+	m.Current = m.Current.Add(d)
+}
+
+// TimeControlMode selects how a player's clock is credited after each move.
+type TimeControlMode int
+
+const (
+	// SUDDEN_DEATH gives each player a fixed amount of time for the round.
+	SUDDEN_DEATH TimeControlMode = iota
+
+	// FISCHER adds Increment to the player's clock after each move.
+	FISCHER
+
+	// BRONSTEIN gives back the time used for a move, up to Increment (the delay).
+	BRONSTEIN
+
+	// BYO_YOMI adds Periods overtime periods of Period each once the main
+	// time is exhausted. A move played within a period does not consume it.
+	BYO_YOMI
+)
+
+// TimeControl describes the time rules of a timed match.
+type TimeControl struct {
+	Mode      TimeControlMode // How the clock is credited after each move
+	Initial   time.Duration   // Main time per player
+	Increment time.Duration   // Fischer increment or Bronstein delay
+	Period    time.Duration   // Length of a byo-yomi period
+	Periods   int             // Number of byo-yomi periods
+}
+
+// String returns a compact description of the time control, in seconds:
+// "300" (sudden death), "300+5" (Fischer), "300d5" (Bronstein) or
+// "300b5x30" (byo-yomi, 5 periods of 30 seconds).
+func (tc TimeControl) String() string {
+	initial := int(tc.Initial.Seconds())
+
+	switch tc.Mode {
+	case FISCHER:
+		return fmt.Sprintf("%d+%d", initial, int(tc.Increment.Seconds()))
+	case BRONSTEIN:
+		return fmt.Sprintf("%dd%d", initial, int(tc.Increment.Seconds()))
+	case BYO_YOMI:
+		return fmt.Sprintf("%db%dx%d", initial, tc.Periods, int(tc.Period.Seconds()))
+	default:
+		return fmt.Sprintf("%d", initial)
+	}
+}
+
+// PlayerTime is a snapshot of one player's clock.
+type PlayerTime struct {
+	Main    time.Duration // Main time left
+	Periods int           // Byo-yomi periods left
+	Period  time.Duration // Time left in the current byo-yomi period
+	Running bool          // Whether this clock is currently running
+	Flagged bool          // Whether the player has run out of time
+}
+
+// noActivePlayer marks a stopped clock.
+const noActivePlayer = -1
+
+// Clock is a multi-player chess clock.
+//
+// Players are identified by their slot (Player.ID). At most one clock runs at
+// a time: Start begins a player's turn and Press ends it, crediting time
+// according to the TimeControl.
+type Clock struct {
+	Control TimeControl
+
+	source    TimeSource
+	remaining []time.Duration // Main time per player, excluding the running turn
+	periods   []int           // Byo-yomi periods left per player
+	active    int             // Player whose clock runs, or noActivePlayer
+	started   time.Time       // Start of the active player's turn
+}
+
+// NewClock creates a stopped clock for the given number of players.
+//
+// If source is nil, SystemTime is used.
+func NewClock(tc TimeControl, players int, source TimeSource) *Clock {
+	if source == nil {
+		source = SystemTime{}
+	}
+	c := &Clock{Control: tc, source: source}
+	c.Reset(players)
+	return c
+}
+
+// clone returns a copy of the clock that evolves independently, or nil if c
+// is nil. Both copies share the time source.
+func (c *Clock) clone() *Clock {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	cp.remaining = slices.Clone(c.remaining)
+	cp.periods = slices.Clone(c.periods)
+	return &cp
+}
+
+// Reset stops the clock and gives every player the initial time again.
+func (c *Clock) Reset(players int) {
+	c.remaining = make([]time.Duration, players)
+	c.periods = make([]int, players)
+	for i := range c.remaining {
+		c.remaining[i] = c.Control.Initial
+		c.periods[i] = c.Control.Periods
+	}
+	c.active = noActivePlayer
+}
+
+// Start starts the clock of the given player (stopping any running clock
+// without crediting it).
+func (c *Clock) Start(player int) {
+	if player < 0 || player >= len(c.remaining) {
+		return
+	}
+	c.active = player
+	c.started = c.source.Now()
+}
+
+// Stop pauses the running clock, charging the elapsed time without any
+// increment or delay.
+func (c *Clock) Stop() {
+	if c.active == noActivePlayer {
+		return
+	}
+	t := c.Time(c.active)
+	c.remaining[c.active] = t.Main
+	c.periods[c.active] = t.Periods
+	c.active = noActivePlayer
+}
+
+// Press ends the active player's move: the elapsed time is charged and the
+// increment, delay or byo-yomi rules are applied. The clock is then stopped.
+func (c *Clock) Press() {
+	if c.active == noActivePlayer {
+		return
+	}
+
+	p := c.active
+	used := c.elapsed()
+
+	switch c.Control.Mode {
+	case FISCHER:
+		c.remaining[p] += c.Control.Increment - used
+
+	case BRONSTEIN:
+		c.remaining[p] -= used - min(used, c.Control.Increment)
+
+	case BYO_YOMI:
+		if used <= c.remaining[p] {
+			c.remaining[p] -= used
+		} else {
+			over := used - c.remaining[p]
+			c.remaining[p] = 0
+			c.periods[p] -= c.periodsLost(over)
+		}
+
+	default:
+		c.remaining[p] -= used
+	}
+
+	if c.remaining[p] < 0 {
+		c.remaining[p] = 0
+	}
+	c.active = noActivePlayer
+}
+
+// Active returns the slot of the player whose clock runs, or -1.
+func (c *Clock) Active() int {
+	return c.active
+}
+
+// Time returns the current state of a player's clock.
+func (c *Clock) Time(player int) PlayerTime {
+	if player < 0 || player >= len(c.remaining) {
+		return PlayerTime{}
+	}
+
+	t := PlayerTime{
+		Main:    c.remaining[player],
+		Periods: c.periods[player],
+		Period:  c.Control.Period,
+		Running: player == c.active,
+	}
+	if !t.Running {
+		return t
+	}
+
+	left := t.Main - c.elapsed()
+	if c.Control.Mode != BYO_YOMI || c.Control.Period <= 0 {
+		t.Main = max(left, 0)
+		t.Flagged = left <= 0
+		return t
+	}
+
+	if left >= 0 {
+		t.Main = left
+		return t
+	}
+
+	// Main time is over: consume byo-yomi periods.
+	over := -left
+	lost := c.periodsLost(over)
+	t.Main = 0
+	t.Periods -= lost
+	t.Period = c.Control.Period - (over - time.Duration(lost)*c.Control.Period)
+	t.Flagged = t.Periods <= 0
+	if t.Flagged {
+		t.Periods = 0
+		t.Period = 0
+	}
+	return t
+}
+
+// Flagged reports whether the active player has run out of time.
+func (c *Clock) Flagged() bool {
+	return c.active != noActivePlayer && c.Time(c.active).Flagged
+}
+
+// MoveBudget returns how much time the player may reasonably spend on the
+// current move, assuming movesLeft more moves until the end of the round.
+//
+// The main time is split evenly over the remaining moves, then the time that
+// is guaranteed to come back (increment, delay or one byo-yomi period) is
+// added. The budget never exceeds what the player can spend without flagging.
+func (c *Clock) MoveBudget(player, movesLeft int) time.Duration {
+	t := c.Time(player)
+	if t.Flagged {
+		return 0
+	}
+	movesLeft = max(movesLeft, 1)
+
+	share := t.Main / time.Duration(movesLeft)
+	switch c.Control.Mode {
+	case FISCHER, BRONSTEIN:
+		return min(share+c.Control.Increment, t.Main)
+	case BYO_YOMI:
+		if t.Main == 0 {
+			return t.Period
+		}
+		return share + c.Control.Period
+	default:
+		return share
+	}
+}
+
+// elapsed returns the time spent by the active player on the current move.
+func (c *Clock) elapsed() time.Duration {
+	if c.active == noActivePlayer {
+		return 0
+	}
+	return c.source.Now().Sub(c.started)
+}
+
+// periodsLost returns how many byo-yomi periods are used up when a move runs
+// over the main time by over. A move within the first period loses none.
+func (c *Clock) periodsLost(over time.Duration) int {
+	if c.Control.Period <= 0 || over <= 0 {
+		return 0
+	}
+	return int((over - 1) / c.Control.Period)
+}
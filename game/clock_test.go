@@ -0,0 +1,204 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// clockStep is one move of a clock scenario: the player moving and how long
+// they think before pressing the clock.
+type clockStep struct {
+	player int
+	think  time.Duration
+}
+
+func TestClockPress(t *testing.T) {
+	tests := []struct {
+		name  string
+		tc    TimeControl
+		steps []clockStep
+		want  []PlayerTime // Clock of each player after the last step
+	}{
+		{
+			name:  "sudden death",
+			tc:    TimeControl{Mode: SUDDEN_DEATH, Initial: 10 * time.Second},
+			steps: []clockStep{{0, 3 * time.Second}, {1, time.Second}, {0, 2 * time.Second}},
+			want:  []PlayerTime{{Main: 5 * time.Second}, {Main: 9 * time.Second}},
+		},
+		{
+			name: "fischer",
+			tc:   TimeControl{Mode: FISCHER, Initial: 10 * time.Second, Increment: 2 * time.Second},
+			// A quick move gains time, beyond the initial time.
+			steps: []clockStep{{0, 3 * time.Second}, {1, time.Second}, {0, 500 * time.Millisecond}},
+			want:  []PlayerTime{{Main: 10*time.Second + 500*time.Millisecond}, {Main: 11 * time.Second}},
+		},
+		{
+			name: "bronstein",
+			tc:   TimeControl{Mode: BRONSTEIN, Initial: 10 * time.Second, Increment: 2 * time.Second},
+			// The delay is given back up to the time used, never more.
+			steps: []clockStep{{0, time.Second}, {1, 5 * time.Second}, {0, 2 * time.Second}},
+			want:  []PlayerTime{{Main: 10 * time.Second}, {Main: 7 * time.Second}},
+		},
+		{
+			name:  "byo-yomi within main time",
+			tc:    TimeControl{Mode: BYO_YOMI, Initial: 5 * time.Second, Period: 10 * time.Second, Periods: 3},
+			steps: []clockStep{{0, 4 * time.Second}, {1, time.Second}},
+			want: []PlayerTime{
+				{Main: time.Second, Periods: 3, Period: 10 * time.Second},
+				{Main: 4 * time.Second, Periods: 3, Period: 10 * time.Second},
+			},
+		},
+		{
+			name: "byo-yomi periods",
+			tc:   TimeControl{Mode: BYO_YOMI, Initial: 5 * time.Second, Period: 10 * time.Second, Periods: 3},
+			steps: []clockStep{
+				{0, 15 * time.Second}, // Main time and the first period, which is kept
+				{1, time.Second},
+				{0, 25 * time.Second}, // Two periods used up
+			},
+			want: []PlayerTime{
+				{Main: 0, Periods: 1, Period: 10 * time.Second},
+				{Main: 4 * time.Second, Periods: 3, Period: 10 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := &ManualTime{Current: time.Unix(0, 0)}
+			c := NewClock(tt.tc, len(tt.want), now)
+
+			for _, step := range tt.steps {
+				c.Start(step.player)
+				now.Advance(step.think)
+				if c.Flagged() {
+					t.Fatalf("player %d flagged after %v", step.player, step.think)
+				}
+				c.Press()
+			}
+
+			if c.Active() != noActivePlayer {
+				t.Errorf("Active() = %d after Press, want %d", c.Active(), noActivePlayer)
+			}
+			for p, want := range tt.want {
+				if got := c.Time(p); got != want {
+					t.Errorf("Time(%d) = %+v, want %+v", p, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClockFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		tc    TimeControl
+		think time.Duration // Time spent on the first move
+		want  PlayerTime    // Clock of player 0 while it still runs
+	}{
+		{
+			name:  "sudden death in time",
+			tc:    TimeControl{Mode: SUDDEN_DEATH, Initial: 10 * time.Second},
+			think: 9 * time.Second,
+			want:  PlayerTime{Main: time.Second, Running: true},
+		},
+		{
+			name:  "sudden death flag",
+			tc:    TimeControl{Mode: SUDDEN_DEATH, Initial: 10 * time.Second},
+			think: 10 * time.Second,
+			want:  PlayerTime{Running: true, Flagged: true},
+		},
+		{
+			name:  "fischer flag",
+			tc:    TimeControl{Mode: FISCHER, Initial: 10 * time.Second, Increment: 5 * time.Second},
+			think: 11 * time.Second,
+			want:  PlayerTime{Running: true, Flagged: true},
+		},
+		{
+			name:  "byo-yomi running period",
+			tc:    TimeControl{Mode: BYO_YOMI, Initial: 5 * time.Second, Period: 10 * time.Second, Periods: 3},
+			think: 30 * time.Second,
+			want:  PlayerTime{Periods: 1, Period: 5 * time.Second, Running: true},
+		},
+		{
+			name:  "byo-yomi flag",
+			tc:    TimeControl{Mode: BYO_YOMI, Initial: 5 * time.Second, Period: 10 * time.Second, Periods: 3},
+			think: 35*time.Second + time.Millisecond,
+			want:  PlayerTime{Running: true, Flagged: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := &ManualTime{Current: time.Unix(0, 0)}
+			c := NewClock(tt.tc, 2, now)
+			c.Start(0)
+			now.Advance(tt.think)
+
+			if got := c.Time(0); got != tt.want {
+				t.Errorf("Time(0) = %+v, want %+v", got, tt.want)
+			}
+			if got := c.Flagged(); got != tt.want.Flagged {
+				t.Errorf("Flagged() = %v, want %v", got, tt.want.Flagged)
+			}
+		})
+	}
+}
+
+func TestGameTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		position   string // Starting position ("" = empty board)
+		wantWinner int    // Winner ID, or -1 for a draw
+	}{
+		{name: "opponent wins", wantWinner: 1},
+		// The opponent has no line left to complete: the match is drawn.
+		{name: "opponent cannot win", position: "3x3/3 aba/abb/ba1 a 2 -", wantWinner: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := &ManualTime{Current: time.Unix(0, 0)}
+			g := NewGame()
+			if tt.position != "" {
+				if err := g.Decode(tt.position); err != nil {
+					t.Fatal(err)
+				}
+			}
+			g.SetTimeControl(&TimeControl{Mode: SUDDEN_DEATH, Initial: 5 * time.Second}, now)
+
+			now.Advance(4 * time.Second)
+			if g.CheckTimeout() {
+				t.Fatal("CheckTimeout reported a timeout before the flag fell")
+			}
+
+			now.Advance(2 * time.Second)
+			if !g.CheckTimeout() {
+				t.Fatal("CheckTimeout did not report the timeout")
+			}
+			if g.State != GAME_END || g.Reason != END_TIMEOUT {
+				t.Errorf("state %v/%v, want %v/%v", g.State, g.Reason, GAME_END, END_TIMEOUT)
+			}
+			if g.Clock.Active() != noActivePlayer {
+				t.Error("clock still running after the timeout")
+			}
+
+			if tt.wantWinner < 0 {
+				if g.Winner != nil {
+					t.Errorf("winner %d, want a draw", g.Winner.ID)
+				}
+			} else {
+				if g.Winner == nil || g.Winner.ID != tt.wantWinner {
+					t.Fatalf("winner %v, want player %d", g.Winner, tt.wantWinner)
+				}
+				if g.Winner.Points != 1 {
+					t.Errorf("winner has %d points, want 1", g.Winner.Points)
+				}
+			}
+
+			if g.PlayMove(2, 2) {
+				t.Error("PlayMove accepted a move after the timeout")
+			}
+		})
+	}
+}
@@ -1,10 +1,5 @@
 package game
 
-import (
-	"GoTicTacToe/assets"
-	"image/color"
-)
-
 // GameState represents the current state of a match.
 type GameState int
 
@@ -16,6 +11,27 @@ const (
 	GAME_END
 )
 
+// EndReason explains why a match ended.
+type EndReason int
+
+const (
+	// END_NONE indicates that the match is still in progress.
+	END_NONE EndReason = iota
+
+	// END_WIN indicates that a player aligned ToWin symbols.
+	END_WIN
+
+	// END_BOARD_FULL indicates a draw because no empty cell remains.
+	END_BOARD_FULL
+
+	// END_DEAD_DRAW indicates a draw because no player can complete a line
+	// anymore, even though empty cells remain (see Game.DeadDrawDetection).
+	END_DEAD_DRAW
+
+	// END_TIMEOUT indicates that the player to move ran out of time.
+	END_TIMEOUT
+)
+
 // Default game configuration for a classic Tic-Tac-Toe match.
 const (
 	DefaultBoardWidth  = 3
@@ -23,16 +39,10 @@ const (
 	DefaultToWin       = 3
 )
 
-// Default player styling (symbols and colors).
+// Default player symbols.
 const (
-	defaultColorAlpha = 255
-)
-
-var (
-	defaultPlayer1Color = color.RGBA{R: 255, G: 0, B: 0, A: defaultColorAlpha} // red
-	defaultPlayer2Color = color.RGBA{R: 0, G: 0, B: 255, A: defaultColorAlpha} // blue
-	defaultPlayer1Sym   = assets.CircleSymbol
-	defaultPlayer2Sym   = assets.CrossSymbol
+	defaultPlayer1Sym = CircleSymbol
+	defaultPlayer2Sym = CrossSymbol
 )
 
 // Game contains all data and logic required to run a match.
@@ -45,6 +55,23 @@ type Game struct {
 	Players []*Player // All players involved in the match
 	Current *Player   // Player whose turn it currently is
 	Winner  *Player   // Winner of the match (nil in case of draw)
+	Reason  EndReason // Why the match ended (END_NONE while playing)
+	Starter *Player   // Player who made (or makes) the first move of the round
+
+	// DeadDrawDetection ends the round as a draw as soon as no player can
+	// complete a line anymore, instead of waiting for the board to be full.
+	DeadDrawDetection bool
+
+	// Clock times the players' moves (nil for an untimed match).
+	// See SetTimeControl.
+	Clock *Clock
+
+	// History lists the moves played since the round started, in order.
+	History []Move
+
+	// StartPosition is the notation of the position the round started from
+	// (see Decode), or "" when it started from an empty board.
+	StartPosition string
 
 	boardWidth  int
 	boardHeight int
@@ -85,37 +112,90 @@ func (g *Game) ResetHardWithPlayers(boardWidth, boardHeight, toWin int, players
 	// Fallback to default players if none provided.
 	if len(players) == 0 {
 		players = []*Player{
-			NewPlayer(assets.NewSymbol(defaultPlayer1Sym), defaultPlayer1Color),
-			NewPlayer(assets.NewSymbol(defaultPlayer2Sym), defaultPlayer2Color),
+			NewPlayer("", defaultPlayer1Sym),
+			NewPlayer("", defaultPlayer2Sym),
 		}
 	}
 
 	g.Players = players
 
-	// Initialize players' scores to zero.
-	for _, p := range g.Players {
+	// Assign player slots and initialize players' scores to zero.
+	for i, p := range g.Players {
+		p.ID = i
 		p.Points = 0
 	}
 
 	g.Current = g.Players[0]
+	g.Starter = g.Current
 	g.Winner = nil
+	g.Reason = END_NONE
 	g.State = PLAYING
+	g.History = nil
+	g.StartPosition = ""
+	g.restartClock()
 }
 
 // Reset clears the board and restarts the match while keeping player scores intact.
 //
-// This is typically used between rounds in the same session.
+// This is typically used between rounds in the same session. The first player
+// in the list moves first; see ResetStartingWith to choose another one.
 func (g *Game) Reset() {
+	g.ResetStartingWith(g.Players[0])
+}
+
+// ResetStartingWith clears the board and restarts the match with first to
+// move, keeping player scores intact.
+//
+// If first is not one of the game's players, the first player in the list
+// moves first.
+func (g *Game) ResetStartingWith(first *Player) {
 	if g.Board == nil {
 		g.Board = NewBoard(g.boardWidth, g.boardHeight, g.toWin)
 	} else {
 		g.Board.Clear()
 	}
 
+	starter := g.Players[0]
+	for _, p := range g.Players {
+		if p == first {
+			starter = p
+		}
+	}
+
 	// Reset match state.
-	g.Current = g.Players[0]
+	g.Current = starter
+	g.Starter = starter
 	g.Winner = nil
+	g.Reason = END_NONE
 	g.State = PLAYING
+	g.History = nil
+	g.StartPosition = ""
+	g.restartClock()
+}
+
+// SetTimeControl enables clocks for the match, or disables them if tc is nil.
+//
+// The clock is read from source (SystemTime if nil) and starts immediately
+// for the current player.
+func (g *Game) SetTimeControl(tc *TimeControl, source TimeSource) {
+	if tc == nil {
+		g.Clock = nil
+		return
+	}
+	g.Clock = NewClock(*tc, len(g.Players), source)
+	g.restartClock()
+}
+
+// restartClock gives every player the initial time again and starts the
+// current player's clock if the match is in progress.
+func (g *Game) restartClock() {
+	if g.Clock == nil {
+		return
+	}
+	g.Clock.Reset(len(g.Players))
+	if g.State == PLAYING && g.Current != nil {
+		g.Clock.Start(g.Current.ID)
+	}
 }
 
 // ResetPoints resets every player's score to zero.
@@ -148,15 +228,26 @@ func (g *Game) NextPlayer() {
 // PlayMove attempts to play a move at (x, y), then updates the match state.
 //
 // It handles:
+// - rejecting moves once the match ended or the player ran out of time
 // - move validation (via Board.Play)
+// - move history and clock
 // - win detection and scoring
-// - draw detection
+// - draw detection (full board, then dead position if enabled)
 // - switching to the next player when the match continues
 func (g *Game) PlayMove(x, y int) bool {
+	// A move can only be played while the match runs and the clock has time left.
+	if g.State != PLAYING || g.CheckTimeout() {
+		return false
+	}
+
 	ok := g.Board.Play(g.Current, x, y)
 	if !ok {
 		return false
 	}
+	g.History = append(g.History, Move{X: x, Y: y})
+	if g.Clock != nil {
+		g.Clock.Press()
+	}
 
 	// Check for victory.
 	if g.CheckWin() {
@@ -170,6 +261,15 @@ func (g *Game) PlayMove(x, y int) bool {
 
 	// Continue the game: switch to next player.
 	g.NextPlayer()
+
+	// End early if nobody can win anymore (needs the next player to move).
+	if g.CheckDeadDraw() {
+		return true
+	}
+
+	if g.Clock != nil {
+		g.Clock.Start(g.Current.ID)
+	}
 	return true
 }
 
@@ -182,6 +282,7 @@ func (g *Game) CheckWin() bool {
 	if p != nil {
 		g.Winner = p
 		g.Winner.Points++
+		g.Reason = END_WIN
 		g.State = GAME_END
 		return true
 	}
@@ -195,8 +296,92 @@ func (g *Game) CheckWin() bool {
 func (g *Game) CheckDraw() bool {
 	if g.Board.CheckDraw() {
 		g.Winner = nil
+		g.Reason = END_BOARD_FULL
 		g.State = GAME_END
 		return true
 	}
 	return false
 }
+
+// CheckDeadDraw checks whether the match is a dead draw.
+//
+// It only applies when DeadDrawDetection is enabled and the match is still in
+// progress. If no player can complete a line anymore (see IsDeadPosition),
+// Winner is set to nil and the match ends with Reason = END_DEAD_DRAW.
+func (g *Game) CheckDeadDraw() bool {
+	if !g.DeadDrawDetection || g.State != PLAYING {
+		return false
+	}
+	if !g.IsDeadPosition() {
+		return false
+	}
+
+	g.Winner = nil
+	g.Reason = END_DEAD_DRAW
+	g.State = GAME_END
+	return true
+}
+
+// CheckTimeout checks whether the current player ran out of time.
+//
+// It should be polled regularly while the match runs (e.g. every frame). When
+// the current player's clock has expired, the clock stops and the match ends
+// with Reason = END_TIMEOUT. In a two-player game the opponent wins and
+// scores, unless it can no longer complete any line, in which case the match
+// is drawn. With more players there is no single opponent to reward, so the
+// match is drawn.
+func (g *Game) CheckTimeout() bool {
+	if g.Clock == nil || g.State != PLAYING || !g.Clock.Flagged() {
+		return false
+	}
+	g.Clock.Stop()
+
+	g.Winner = nil
+	if len(g.Players) == 2 {
+		opp := g.Current.Opponent(g.Players)
+		if opp != nil && g.Board.CanComplete(opp, g.Board.EmptyCount()) {
+			g.Winner = opp
+			g.Winner.Points++
+		}
+	}
+
+	g.Reason = END_TIMEOUT
+	g.State = GAME_END
+	return true
+}
+
+// IsDeadPosition reports whether no player can complete a line anymore.
+//
+// Remaining moves are distributed in turn order starting with Current: with
+// E empty cells and N players, the player k turns away from Current can still
+// play ceil((E-k)/N) tokens. A player is still alive if some window can be
+// filled with that many tokens (see Board.CanComplete).
+func (g *Game) IsDeadPosition() bool {
+	n := len(g.Players)
+	if n == 0 {
+		return false
+	}
+
+	start := 0
+	for i, p := range g.Players {
+		if p == g.Current {
+			start = i
+			break
+		}
+	}
+
+	empty := g.Board.EmptyCount()
+	for k := 0; k < n; k++ {
+		movesLeft := 0
+		if empty > k {
+			movesLeft = (empty - k + n - 1) / n
+		}
+
+		p := g.Players[(start+k)%n]
+		if g.Board.CanComplete(p, movesLeft) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Snapshot is an immutable view of a game, taken between two mutations.
+//
+// Every field is a private copy: the board cells and the Current/Winner
+// pointers refer to the snapshot's own Players, never to the live players.
+// Snapshots must not be modified, so they can be shared freely between
+// goroutines (renderer, AI, network server...).
+//
+// The clock copy keeps running: while the snapshot is the latest one, its
+// Time reports the time left now, so clocks can be drawn without locking.
+type Snapshot struct {
+	Version uint64    // Incremented by every mutation applied through the Handle
+	Board   *Board    // Copy of the board
+	Players []*Player // Copies of the players, with their scores (Points)
+	Current *Player   // Player to move
+	Starter *Player   // Player who made the first move of the round
+	Winner  *Player   // Winner of the round (nil while playing or for a draw)
+	State   GameState // Playing or ended
+	Reason  EndReason // Why the round ended (END_NONE while playing)
+	History []Move    // Moves played since the round started
+	Clock   *Clock    // Copy of the clock (nil for an untimed match)
+}
+
+// Player returns the snapshot's copy of the player with the given ID, or nil.
+func (s *Snapshot) Player(id int) *Player {
+	if id < 0 || id >= len(s.Players) {
+		return nil
+	}
+	return s.Players[id]
+}
+
+// Handle gives concurrent access to a Game.
+//
+// Mutations are applied one at a time under a lock, and each one publishes a
+// new Snapshot. Readers call Snapshot, which never blocks: the renderer can
+// draw the latest snapshot while an AI or a network client plays a move.
+//
+// Once a Game is wrapped, it must only be mutated through its Handle.
+type Handle struct {
+	mu      sync.Mutex
+	game    *Game
+	version uint64
+
+	snapshot atomic.Pointer[Snapshot]
+}
+
+// NewHandle wraps g and publishes its first snapshot (version 0).
+func NewHandle(g *Game) *Handle {
+	h := &Handle{game: g}
+	h.publish()
+	return h
+}
+
+// Snapshot returns the latest published snapshot. It never blocks.
+func (h *Handle) Snapshot() *Snapshot {
+	return h.snapshot.Load()
+}
+
+// Version returns the version of the latest published snapshot.
+func (h *Handle) Version() uint64 {
+	return h.Snapshot().Version
+}
+
+// PlayMove plays a move for the current player (see Game.PlayMove) and
+// returns the resulting snapshot. The snapshot is unchanged if the move is
+// rejected.
+func (h *Handle) PlayMove(x, y int) (*Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.game.PlayMove(x, y) {
+		return h.Snapshot(), false
+	}
+	return h.publish(), true
+}
+
+// TryMove plays a move only if the game is still at version.
+//
+// It lets a reader that decided a move from a snapshot (e.g. an AI thinking
+// in the background) play it without risking to apply it to a position that
+// changed in the meantime.
+func (h *Handle) TryMove(version uint64, x, y int) (*Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.version != version || !h.game.PlayMove(x, y) {
+		return h.Snapshot(), false
+	}
+	return h.publish(), true
+}
+
+// Do runs fn with exclusive access to the game and publishes a new snapshot
+// if fn reports that it changed the game. Use it for any mutation other than
+// a move (reset, decode, match rounds, timeout checks...).
+//
+// fn must not keep references to the game or call other Handle methods.
+func (h *Handle) Do(fn func(g *Game) bool) *Snapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !fn(h.game) {
+		return h.Snapshot()
+	}
+	return h.publish()
+}
+
+// Read runs fn with exclusive access to the game without publishing a new
+// snapshot. fn must not mutate the game.
+func (h *Handle) Read(fn func(g *Game)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fn(h.game)
+}
+
+// publish builds and stores a snapshot of the current state. The caller
+// must hold the lock (or own the handle exclusively).
+func (h *Handle) publish() *Snapshot {
+	if h.snapshot.Load() != nil {
+		h.version++
+	}
+	s := h.game.snapshot(h.version)
+	h.snapshot.Store(s)
+	return s
+}
+
+// snapshot returns a deep copy of the game state tagged with version.
+func (g *Game) snapshot(version uint64) *Snapshot {
+	players := make([]*Player, len(g.Players))
+	byPtr := make(map[*Player]*Player, len(g.Players))
+	for i, p := range g.Players {
+		cp := *p
+		players[i] = &cp
+		byPtr[p] = &cp
+	}
+
+	board := NewBoard(g.Board.Width, g.Board.Height, g.Board.ToWin)
+	for x := 0; x < g.Board.Width; x++ {
+		for y := 0; y < g.Board.Height; y++ {
+			if p := g.Board.Cells[x][y]; p != nil {
+				board.Cells[x][y] = byPtr[p]
+			}
+		}
+	}
+	board.hash = g.Board.hash
+
+	return &Snapshot{
+		Version: version,
+		Board:   board,
+		Players: players,
+		Current: byPtr[g.Current],
+		Starter: byPtr[g.Starter],
+		Winner:  byPtr[g.Winner],
+		State:   g.State,
+		Reason:  g.Reason,
+		History: append([]Move(nil), g.History...),
+		Clock:   g.Clock.clone(),
+	}
+}
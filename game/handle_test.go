@@ -0,0 +1,32 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnapshotClock(t *testing.T) {
+	now := &ManualTime{Current: time.Unix(0, 0)}
+	g := NewGame()
+	g.SetTimeControl(&TimeControl{Mode: SUDDEN_DEATH, Initial: time.Minute}, now)
+	h := NewHandle(g)
+
+	snap := h.Snapshot()
+	now.Advance(10 * time.Second)
+	if got := snap.Clock.Time(0); got.Main != 50*time.Second || !got.Running {
+		t.Errorf("snapshot clock of player 0 = %+v, want 50s running", got)
+	}
+
+	// Later moves publish a new clock and leave the old snapshot untouched.
+	next, ok := h.PlayMove(1, 1)
+	if !ok {
+		t.Fatal("move refused")
+	}
+	now.Advance(5 * time.Second)
+	if got := next.Clock.Time(1); got.Main != 55*time.Second || !got.Running {
+		t.Errorf("new snapshot clock of player 1 = %+v, want 55s running", got)
+	}
+	if got := snap.Clock.Time(1); got.Main != time.Minute || got.Running {
+		t.Errorf("old snapshot clock of player 1 = %+v, want 1m stopped", got)
+	}
+}
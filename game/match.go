@@ -0,0 +1,375 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// MatchFormat defines when a match (a series of rounds) is over.
+type MatchFormat int
+
+const (
+	// FIRST_TO ends the match as soon as a player has won Target rounds.
+	FIRST_TO MatchFormat = iota
+
+	// BEST_OF plays at most Target rounds and ends as soon as the leader
+	// cannot be caught anymore.
+	BEST_OF
+)
+
+// RotationPolicy selects who plays first in the next round.
+type RotationPolicy int
+
+const (
+	// ROTATE_ALTERNATE gives the first move to the next player in turn order.
+	ROTATE_ALTERNATE RotationPolicy = iota
+
+	// ROTATE_LOSER_STARTS gives the first move to the player after the winner
+	// (the loser in a two-player game). Draws fall back to ROTATE_ALTERNATE.
+	ROTATE_LOSER_STARTS
+
+	// ROTATE_WINNER_STARTS gives the first move to the winner.
+	// Draws fall back to ROTATE_ALTERNATE.
+	ROTATE_WINNER_STARTS
+
+	// ROTATE_RANDOM picks the first player at random.
+	ROTATE_RANDOM
+)
+
+// TiebreakRule decides a match whose regular rounds ended in a tie.
+type TiebreakRule int
+
+const (
+	// TIEBREAK_DRAW declares the match drawn.
+	TIEBREAK_DRAW TiebreakRule = iota
+
+	// TIEBREAK_SUDDEN_DEATH plays extra rounds until a single player leads.
+	TIEBREAK_SUDDEN_DEATH
+
+	// TIEBREAK_FEWEST_MOVES awards the match to the tied leader who needed the
+	// fewest moves in total for their won rounds. Remaining ties are drawn.
+	TIEBREAK_FEWEST_MOVES
+)
+
+// MatchState represents the current state of a match.
+type MatchState int
+
+const (
+	// MATCH_PLAYING indicates that the match is in progress.
+	MATCH_PLAYING MatchState = iota
+
+	// MATCH_END indicates that the match is decided (won or drawn).
+	MATCH_END
+)
+
+// MatchConfig describes the rules of a match.
+type MatchConfig struct {
+	Format   MatchFormat    // When the match is over
+	Target   int            // N in "first to N" / "best of N" (0 = endless series)
+	Rotation RotationPolicy // Who starts the next round
+	Tiebreak TiebreakRule   // How a tied match is decided
+	Seed     int64          // Seed for ROTATE_RANDOM (0 = time-based)
+}
+
+// String returns a short description such as "First to 3" or "Best of 5".
+func (c MatchConfig) String() string {
+	switch {
+	case c.Target <= 0:
+		return "Endless series"
+	case c.Format == BEST_OF:
+		return fmt.Sprintf("Best of %d", c.Target)
+	default:
+		return fmt.Sprintf("First to %d", c.Target)
+	}
+}
+
+// RoundResult summarizes a finished round.
+type RoundResult struct {
+	Starter *Player   // Player who moved first
+	Winner  *Player   // Winner of the round (nil for a draw)
+	Reason  EndReason // Why the round ended
+	Moves   int       // Number of moves played in the round
+}
+
+// Match runs a series of rounds of the same Game.
+//
+// Rounds are scored with Player.Points (one point per round won). The match
+// does not drive the game loop: call RecordRound once a round ended, then
+// NextRound to start the following one.
+type Match struct {
+	Config MatchConfig
+	Game   *Game
+	State  MatchState
+	Winner *Player       // Winner of the match (nil while playing or if drawn)
+	Rounds []RoundResult // Finished rounds, in order
+
+	// Position is the notation every round starts from ("" = empty board),
+	// taken from Game.StartPosition when the match is created. A position
+	// fixes the side to move, so the rotation policy only applies to rounds
+	// starting from an empty board.
+	Position string
+
+	rng         *rand.Rand
+	tiebreak    bool // Whether sudden-death tiebreak rounds are being played
+	roundLogged bool // Whether the current round was already recorded
+}
+
+// NewMatch starts a new match on g. Scores are reset and the first round begins.
+//
+// If g was set up from a position (see Game.Decode), every round of the match
+// starts from that position.
+func NewMatch(g *Game, cfg MatchConfig) *Match {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	m := &Match{
+		Config:   cfg,
+		Game:     g,
+		Position: g.StartPosition,
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+	m.Restart()
+	return m
+}
+
+// Restart resets scores and history and starts the first round of a new match.
+func (m *Match) Restart() {
+	m.State = MATCH_PLAYING
+	m.Winner = nil
+	m.Rounds = nil
+	m.tiebreak = false
+	m.roundLogged = false
+
+	m.Game.ResetPoints()
+	first := m.Game.Players[0]
+	if m.Config.Rotation == ROTATE_RANDOM {
+		first = m.Game.Players[m.rng.Intn(len(m.Game.Players))]
+	}
+	m.startRound(first)
+}
+
+// WinsNeeded returns how many round wins guarantee the match, or 0 for an
+// endless series.
+func (m *Match) WinsNeeded() int {
+	if m.Config.Target <= 0 {
+		return 0
+	}
+	if m.Config.Format == BEST_OF {
+		return m.Config.Target/2 + 1
+	}
+	return m.Config.Target
+}
+
+// RecordRound records the result of the current round once it has ended and
+// decides the match if possible.
+//
+// It returns true when a result was recorded. Calling it while the round is
+// in progress, or several times for the same round, does nothing.
+func (m *Match) RecordRound() bool {
+	g := m.Game
+	if g.State != GAME_END || m.roundLogged || m.State == MATCH_END {
+		return false
+	}
+	m.roundLogged = true
+
+	m.Rounds = append(m.Rounds, RoundResult{
+		Starter: g.Starter,
+		Winner:  g.Winner,
+		Reason:  g.Reason,
+		Moves:   len(g.History),
+	})
+
+	m.decide()
+	return true
+}
+
+// NextRound starts the next round with the first player chosen by the
+// rotation policy. It returns false if the match is over.
+//
+// A round that is still in progress is abandoned: it is not recorded, scores
+// nobody and does not move the rotation, so the next round starts with the
+// same player.
+func (m *Match) NextRound() bool {
+	if m.State == MATCH_END {
+		return false
+	}
+	m.RecordRound()
+	if m.State == MATCH_END {
+		return false
+	}
+
+	first := m.nextStarter()
+	m.roundLogged = false
+	m.startRound(first)
+	return true
+}
+
+// startRound clears the board, or sets up Position, with first to move.
+func (m *Match) startRound(first *Player) {
+	m.Game.ResetStartingWith(first)
+	if m.Position == "" {
+		return
+	}
+	// The position already decoded once; should it fail, Decode leaves the
+	// empty board set up above.
+	if err := m.Game.Decode(m.Position); err != nil {
+		m.Position = ""
+	}
+}
+
+// Leaders returns the players with the most round wins.
+func (m *Match) Leaders() []*Player {
+	var leaders []*Player
+	best := -1
+	for _, p := range m.Game.Players {
+		switch {
+		case p.Points > best:
+			best = p.Points
+			leaders = []*Player{p}
+		case p.Points == best:
+			leaders = append(leaders, p)
+		}
+	}
+	return leaders
+}
+
+// decide ends the match when its outcome is known.
+func (m *Match) decide() {
+	if m.Config.Target <= 0 {
+		return
+	}
+
+	leaders := m.Leaders()
+
+	// Sudden death: play on until a single leader emerges.
+	if m.tiebreak {
+		if len(leaders) == 1 {
+			m.end(leaders[0])
+		}
+		return
+	}
+
+	if m.Config.Format == FIRST_TO {
+		if leaders[0].Points >= m.Config.Target {
+			m.end(leaders[0])
+		}
+		return
+	}
+
+	// Best of N: stop early once the leader cannot be caught.
+	remaining := m.Config.Target - len(m.Rounds)
+	if len(leaders) == 1 && leaders[0].Points > m.runnerUpPoints()+remaining {
+		m.end(leaders[0])
+		return
+	}
+	if remaining > 0 {
+		return
+	}
+
+	if len(leaders) == 1 {
+		m.end(leaders[0])
+		return
+	}
+	m.breakTie(leaders)
+}
+
+// breakTie applies the tiebreak rule to a tied best-of-N match.
+func (m *Match) breakTie(leaders []*Player) {
+	switch m.Config.Tiebreak {
+	case TIEBREAK_SUDDEN_DEATH:
+		m.tiebreak = true
+
+	case TIEBREAK_FEWEST_MOVES:
+		var best *Player
+		bestMoves, tied := 0, false
+		for _, p := range leaders {
+			moves := m.movesInWins(p)
+			switch {
+			case best == nil || moves < bestMoves:
+				best, bestMoves, tied = p, moves, false
+			case moves == bestMoves:
+				tied = true
+			}
+		}
+		if tied {
+			best = nil
+		}
+		m.end(best)
+
+	default:
+		m.end(nil)
+	}
+}
+
+// end closes the match with the given winner (nil for a draw).
+func (m *Match) end(winner *Player) {
+	m.Winner = winner
+	m.State = MATCH_END
+}
+
+// runnerUpPoints returns the best score among players who are not leading.
+func (m *Match) runnerUpPoints() int {
+	leaders := m.Leaders()
+	best := 0
+	for _, p := range m.Game.Players {
+		if p != leaders[0] && p.Points > best {
+			best = p.Points
+		}
+	}
+	return best
+}
+
+// movesInWins returns the total number of moves of the rounds won by p.
+func (m *Match) movesInWins(p *Player) int {
+	total := 0
+	for _, r := range m.Rounds {
+		if r.Winner == p {
+			total += r.Moves
+		}
+	}
+	return total
+}
+
+// nextStarter applies the rotation policy to pick the next first player.
+//
+// It must be called before the current round is reset. If the current round
+// was abandoned (not recorded), its starter is kept: for the first round, the
+// player Restart picked.
+func (m *Match) nextStarter() *Player {
+	players := m.Game.Players
+	if !m.roundLogged || len(m.Rounds) == 0 {
+		if m.Game.Starter != nil {
+			return m.Game.Starter
+		}
+		return players[0]
+	}
+	last := m.Rounds[len(m.Rounds)-1]
+
+	switch m.Config.Rotation {
+	case ROTATE_RANDOM:
+		return players[m.rng.Intn(len(players))]
+	case ROTATE_WINNER_STARTS:
+		if last.Winner != nil {
+			return last.Winner
+		}
+	case ROTATE_LOSER_STARTS:
+		if last.Winner != nil {
+			return m.playerAfter(last.Winner)
+		}
+	}
+	return m.playerAfter(last.Starter)
+}
+
+// playerAfter returns the player following p in turn order.
+func (m *Match) playerAfter(p *Player) *Player {
+	players := m.Game.Players
+	for i, candidate := range players {
+		if candidate == p {
+			return players[(i+1)%len(players)]
+		}
+	}
+	return players[0]
+}
@@ -0,0 +1,75 @@
+package game
+
+import "testing"
+
+func TestMatchNextRoundAbandoned(t *testing.T) {
+	g := NewGame()
+	m := NewMatch(g, MatchConfig{Rotation: ROTATE_ALTERNATE})
+
+	// Abandoning the first round must not move the rotation (nor panic).
+	g.PlayMove(1, 1)
+	if !m.NextRound() {
+		t.Fatal("NextRound ended an endless series")
+	}
+	if len(m.Rounds) != 0 {
+		t.Fatalf("abandoned round was recorded: %+v", m.Rounds)
+	}
+	if g.Starter != g.Players[0] || g.Current != g.Players[0] {
+		t.Errorf("round after an abandoned first round starts with %v, want player 0", g.Starter.ID)
+	}
+
+	// A finished round passes the first move on.
+	for _, mv := range []Move{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}} {
+		g.PlayMove(mv.X, mv.Y)
+	}
+	m.NextRound()
+	if g.Starter != g.Players[1] {
+		t.Errorf("round after a finished round starts with %v, want player 1", g.Starter.ID)
+	}
+
+	// Abandoning a later round keeps its starter.
+	g.PlayMove(2, 2)
+	m.NextRound()
+	if g.Starter != g.Players[1] || len(m.Rounds) != 1 {
+		t.Errorf("after an abandoned round: starter %v, %d rounds; want player 1, 1 round",
+			g.Starter.ID, len(m.Rounds))
+	}
+}
+
+func TestMatchKeepsStartPosition(t *testing.T) {
+	const position = "3x3/3 a2/1b1/3 a 2 -"
+
+	g := NewGame()
+	if err := g.Decode(position); err != nil {
+		t.Fatal(err)
+	}
+	m := NewMatch(g, MatchConfig{Format: FIRST_TO, Target: 3})
+
+	for round := 1; round <= 2; round++ {
+		if got := g.Encode(); got != position {
+			t.Fatalf("round %d starts from %q, want %q", round, got, position)
+		}
+		// Player a wins along the left column.
+		for _, mv := range []Move{{0, 1}, {2, 2}, {0, 2}} {
+			if !g.PlayMove(mv.X, mv.Y) {
+				t.Fatalf("round %d: move %v refused", round, mv)
+			}
+		}
+		if !m.NextRound() {
+			t.Fatalf("round %d: match over too early", round)
+		}
+	}
+
+	if got := g.Encode(); got != position {
+		t.Errorf("third round starts from %q, want %q", got, position)
+	}
+	if g.Players[0].Points != 2 {
+		t.Errorf("player a has %d points, want 2", g.Players[0].Points)
+	}
+
+	m.Restart()
+	if got := g.Encode(); got != position || g.Players[0].Points != 0 {
+		t.Errorf("restarted match starts from %q with %d points, want %q with 0",
+			got, g.Players[0].Points, position)
+	}
+}
@@ -1,5 +1,10 @@
 package game
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // Move represents a single move on the game board.
 //
 // X and Y are zero-based coordinates referring to a cell in the board
@@ -8,3 +13,43 @@ type Move struct {
 	X int // Column index
 	Y int // Row index
 }
+
+// Coordinate notation constants.
+//
+// A move is written as a column letter followed by a 1-based row number,
+// counted from the top of the board (e.g. "a1" is the top-left cell and
+// "c2" is X = 2, Y = 1).
+const (
+	firstColumnLetter = 'a'
+	lastColumnLetter  = 'z'
+	firstRowNumber    = 1
+)
+
+// String returns the move in coordinate notation (e.g. "b3").
+func (m Move) String() string {
+	if m.X < 0 || m.X > lastColumnLetter-firstColumnLetter || m.Y < 0 {
+		return fmt.Sprintf("(%d,%d)", m.X, m.Y)
+	}
+	return string(rune(firstColumnLetter+m.X)) + strconv.Itoa(m.Y+firstRowNumber)
+}
+
+// ParseMove parses a move written in coordinate notation (see Move.String).
+//
+// It only checks the syntax: bounds must be validated against a board.
+func ParseMove(s string) (Move, error) {
+	if len(s) < 2 || s[0] < firstColumnLetter || s[0] > lastColumnLetter {
+		return Move{}, fmt.Errorf("invalid move %q: expected a column letter followed by a row number", s)
+	}
+
+	// Reject signs and leading zeros, which Atoi would accept.
+	if s[1] < '1' || s[1] > '9' {
+		return Move{}, fmt.Errorf("invalid move %q: row must be a positive integer", s)
+	}
+
+	row, err := strconv.Atoi(s[1:])
+	if err != nil || row < firstRowNumber {
+		return Move{}, fmt.Errorf("invalid move %q: row must be a positive integer", s)
+	}
+
+	return Move{X: int(s[0] - firstColumnLetter), Y: row - firstRowNumber}, nil
+}
@@ -0,0 +1,419 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Position notation
+//
+// A position is written as space-separated fields, similar to FEN in chess:
+//
+//	<width>x<height>/<toWin> <ranks> <side> <players> <flags>
+//
+// Example (3x3 board, O in the top-left corner, X in the center, O to move):
+//
+//	3x3/3 a2/1b1/3 a 2 -
+//
+//   - ranks lists the rows from top (y = 0) to bottom, separated by '/'.
+//     Within a rank, a lowercase letter is a token of the player with that ID
+//     ('a' = player 0, 'b' = player 1, ...) and a number is a run of empty cells.
+//   - side is the letter of the player to move.
+//   - players is the number of players in the match.
+//   - flags is a comma-separated list of variant flags, or '-' when none is set.
+//
+// Board.Encode only writes the first two fields, since a board does not know
+// the turn order.
+
+// Notation syntax constants.
+const (
+	notationFieldSep   = " "
+	notationRankSep    = "/"
+	notationDimSep     = "x"
+	notationToWinSep   = "/"
+	notationFlagSep    = ","
+	notationNoFlags    = "-"
+	notationFirstToken = 'a'
+	notationLastToken  = 'z'
+
+	// boardNotationFields is the number of fields in a board notation.
+	boardNotationFields = 2
+
+	// gameNotationFields is the number of fields in a game notation.
+	gameNotationFields = 5
+
+	// maxNotationPlayers is the number of distinct player letters.
+	maxNotationPlayers = notationLastToken - notationFirstToken + 1
+)
+
+// ErrInvalidNotation is wrapped by every error returned when decoding a
+// position. Use errors.Is to test for it.
+var ErrInvalidNotation = errors.New("invalid position notation")
+
+// notationFlags lists the variant flags accepted in a game notation.
+//
+// Each entry maps the flag name to a getter and setter on Game, so that
+// Encode and Decode stay symmetrical when new variants are added.
+var notationFlags = map[string]struct {
+	get func(g *Game) bool
+	set func(g *Game, on bool)
+}{
+	// deaddraw: Game.DeadDrawDetection is enabled.
+	"deaddraw": {
+		get: func(g *Game) bool { return g.DeadDrawDetection },
+		set: func(g *Game, on bool) { g.DeadDrawDetection = on },
+	},
+}
+
+// notationError builds a descriptive decoding error wrapping ErrInvalidNotation.
+func notationError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidNotation, fmt.Sprintf(format, args...))
+}
+
+// Encode returns the notation of the board: dimensions, ToWin and ranks.
+//
+// Player tokens are written using their ID (see Player.ID).
+func (b *Board) Encode() string {
+	var sb strings.Builder
+
+	sb.WriteString(strconv.Itoa(b.Width))
+	sb.WriteString(notationDimSep)
+	sb.WriteString(strconv.Itoa(b.Height))
+	sb.WriteString(notationToWinSep)
+	sb.WriteString(strconv.Itoa(b.ToWin))
+	sb.WriteString(notationFieldSep)
+
+	for y := 0; y < b.Height; y++ {
+		if y > 0 {
+			sb.WriteString(notationRankSep)
+		}
+
+		empty := 0
+		for x := 0; x < b.Width; x++ {
+			p := b.Cells[x][y]
+			if p == nil {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			sb.WriteByte(playerLetter(p.ID))
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+	}
+
+	return sb.String()
+}
+
+// Decode replaces the board with the position described by s.
+//
+// s must contain exactly the two board fields (see Encode). Tokens are mapped
+// to players by ID: letter 'a' refers to players[0], 'b' to players[1], etc.
+// On error, the board is left unchanged.
+func (b *Board) Decode(s string, players []*Player) error {
+	fields := strings.Fields(s)
+	if len(fields) != boardNotationFields {
+		return notationError("board notation has %d fields, want %d", len(fields), boardNotationFields)
+	}
+
+	decoded, err := decodeBoard(fields[0], fields[1], players)
+	if err != nil {
+		return err
+	}
+
+	*b = *decoded
+	return nil
+}
+
+// Encode returns the full notation of the match position: board, side to move,
+// number of players and variant flags.
+func (g *Game) Encode() string {
+	side := 0
+	if g.Current != nil {
+		side = g.Current.ID
+	}
+
+	flags := make([]string, 0, len(notationFlags))
+	for name, f := range notationFlags {
+		if f.get(g) {
+			flags = append(flags, name)
+		}
+	}
+	sort.Strings(flags)
+
+	flagField := notationNoFlags
+	if len(flags) > 0 {
+		flagField = strings.Join(flags, notationFlagSep)
+	}
+
+	return strings.Join([]string{
+		g.Board.Encode(),
+		string(playerLetter(side)),
+		strconv.Itoa(len(g.Players)),
+		flagField,
+	}, notationFieldSep)
+}
+
+// Decode sets up the match from the position described by s.
+//
+// The number of players in s must match len(g.Players). The board is replaced
+// (views holding the previous *Board must be rebuilt), the side to move becomes
+// Current, and the match state is derived from the position: a position that
+// already contains a winner or a full board starts in GAME_END. The history is
+// cleared and StartPosition records the decoded position. Scores are not
+// modified. The position must be reachable by legal play: token counts must
+// agree with the side to move and at most one player may own a line. On error,
+// the game is left unchanged.
+func (g *Game) Decode(s string) error {
+	fields := strings.Fields(s)
+	if len(fields) != gameNotationFields {
+		return notationError("game notation has %d fields, want %d", len(fields), gameNotationFields)
+	}
+
+	count, err := strconv.Atoi(fields[3])
+	if err != nil || count < 1 {
+		return notationError("player count %q is not a positive integer", fields[3])
+	}
+	if count != len(g.Players) {
+		return notationError("position has %d players, game has %d", count, len(g.Players))
+	}
+
+	board, err := decodeBoard(fields[0], fields[1], g.Players)
+	if err != nil {
+		return err
+	}
+
+	side, err := decodePlayerLetter(fields[2], count)
+	if err != nil {
+		return notationError("side to move: %v", err)
+	}
+	if err := checkTurnOrder(board, side, count); err != nil {
+		return err
+	}
+
+	flags, err := decodeFlags(fields[4])
+	if err != nil {
+		return err
+	}
+
+	g.Board = board
+	g.boardWidth = board.Width
+	g.boardHeight = board.Height
+	g.toWin = board.ToWin
+	for name, f := range notationFlags {
+		f.set(g, flags[name])
+	}
+
+	g.Current = g.Players[side]
+	g.Starter = g.Current
+	g.Winner = nil
+	g.Reason = END_NONE
+	g.State = PLAYING
+	g.History = nil
+	g.StartPosition = g.Encode()
+
+	// Derive the match state without awarding points.
+	if w := g.Board.CheckWin(); w != nil {
+		g.Winner = w
+		g.Reason = END_WIN
+		g.State = GAME_END
+	} else if g.Board.CheckDraw() {
+		g.Reason = END_BOARD_FULL
+		g.State = GAME_END
+	} else {
+		g.CheckDeadDraw()
+	}
+	g.restartClock()
+	return nil
+}
+
+// decodeBoard parses the dimension and rank fields into a new board.
+func decodeBoard(dimField, rankField string, players []*Player) (*Board, error) {
+	width, height, toWin, err := decodeDimensions(dimField)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := strings.Split(rankField, notationRankSep)
+	if len(ranks) != height {
+		return nil, notationError("found %d ranks, want %d (board height)", len(ranks), height)
+	}
+
+	b := NewBoard(width, height, toWin)
+	for y, rank := range ranks {
+		x := 0
+		for i := 0; i < len(rank); {
+			c := rank[i]
+
+			switch {
+			case c >= '0' && c <= '9':
+				j := i
+				for j < len(rank) && rank[j] >= '0' && rank[j] <= '9' {
+					j++
+				}
+				if c == '0' {
+					return nil, notationError("rank %d: empty run %q starts with 0", y+1, rank[i:j])
+				}
+				run, _ := strconv.Atoi(rank[i:j])
+				x += run
+				i = j
+
+			case c >= notationFirstToken && c <= notationLastToken:
+				id := int(c - notationFirstToken)
+				if id >= len(players) {
+					return nil, notationError("rank %d: token %q refers to player %d, only %d players",
+						y+1, c, id+1, len(players))
+				}
+				if x >= width {
+					return nil, notationError("rank %d has more than %d cells", y+1, width)
+				}
+				b.Play(players[id], x, y)
+				x++
+				i++
+
+			default:
+				return nil, notationError("rank %d: unexpected character %q", y+1, c)
+			}
+		}
+
+		if x != width {
+			return nil, notationError("rank %d has %d cells, want %d", y+1, x, width)
+		}
+	}
+
+	// The round stops at the first complete line, so two players cannot own one.
+	if owners := b.winners(); len(owners) > 1 {
+		return nil, notationError("players %q and %q both have a complete line",
+			playerLetter(owners[0].ID), playerLetter(owners[1].ID))
+	}
+
+	return b, nil
+}
+
+// checkTurnOrder checks that the position of board can be reached by legal
+// play with side to move: players take turns in ID order from some starter,
+// so token counts differ by at most one and the players ahead are the ones
+// who moved last. Once the round is over (a line or a full board), side may
+// also be the player who made the last move, as Game.Encode writes it, and a
+// line must belong to that player.
+func checkTurnOrder(b *Board, side, count int) error {
+	tokens := make([]int, count)
+	total := 0
+	for x := range b.Cells {
+		for y := range b.Cells[x] {
+			if p := b.Cells[x][y]; p != nil {
+				tokens[p.ID]++
+				total++
+			}
+		}
+	}
+
+	owners := b.winners()
+	over := len(owners) > 0 || b.EmptyCount() == 0
+
+	for starter := 0; starter < count; starter++ {
+		if !tokensFrom(tokens, starter, total) {
+			continue
+		}
+		next := (starter + total) % count
+		last := (next + count - 1) % count
+		if len(owners) > 0 && owners[0].ID != last {
+			continue
+		}
+		if side == next || (over && side == last) {
+			return nil
+		}
+	}
+	return notationError("token counts %v cannot be reached by legal play with %q to move",
+		tokens, playerLetter(side))
+}
+
+// tokensFrom reports whether tokens holds the number of tokens each player
+// has after total moves played in turn order from player starter.
+func tokensFrom(tokens []int, starter, total int) bool {
+	count := len(tokens)
+	for id, n := range tokens {
+		want := total / count
+		if (id-starter+count)%count < total%count {
+			want++
+		}
+		if n != want {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeDimensions parses a "<width>x<height>/<toWin>" field.
+func decodeDimensions(field string) (width, height, toWin int, err error) {
+	dims, win, ok := strings.Cut(field, notationToWinSep)
+	if !ok {
+		return 0, 0, 0, notationError("dimensions %q: missing %q before toWin", field, notationToWinSep)
+	}
+	w, h, ok := strings.Cut(dims, notationDimSep)
+	if !ok {
+		return 0, 0, 0, notationError("dimensions %q: expected <width>%s<height>", field, notationDimSep)
+	}
+
+	if width, err = strconv.Atoi(w); err != nil || width < 1 {
+		return 0, 0, 0, notationError("width %q is not a positive integer", w)
+	}
+	if height, err = strconv.Atoi(h); err != nil || height < 1 {
+		return 0, 0, 0, notationError("height %q is not a positive integer", h)
+	}
+	if toWin, err = strconv.Atoi(win); err != nil || toWin < 1 {
+		return 0, 0, 0, notationError("toWin %q is not a positive integer", win)
+	}
+
+	minDim := min(width, height)
+	if toWin > minDim {
+		return 0, 0, 0, notationError("toWin %d exceeds the smallest board dimension %d", toWin, minDim)
+	}
+	return width, height, toWin, nil
+}
+
+// decodePlayerLetter parses a single player letter and checks it against count.
+func decodePlayerLetter(field string, count int) (int, error) {
+	if len(field) != 1 || field[0] < notationFirstToken || field[0] > notationLastToken {
+		return 0, fmt.Errorf("%q is not a player letter", field)
+	}
+	id := int(field[0] - notationFirstToken)
+	if id >= count {
+		return 0, fmt.Errorf("player %q does not exist in a %d-player game", field, count)
+	}
+	return id, nil
+}
+
+// decodeFlags parses the flag field into a set of enabled flags.
+func decodeFlags(field string) (map[string]bool, error) {
+	flags := map[string]bool{}
+	if field == notationNoFlags {
+		return flags, nil
+	}
+
+	for _, name := range strings.Split(field, notationFlagSep) {
+		if _, ok := notationFlags[name]; !ok {
+			return nil, notationError("unknown variant flag %q", name)
+		}
+		if flags[name] {
+			return nil, notationError("variant flag %q is repeated", name)
+		}
+		flags[name] = true
+	}
+	return flags, nil
+}
+
+// playerLetter returns the notation letter of a player ID.
+func playerLetter(id int) byte {
+	if id < 0 || id >= maxNotationPlayers {
+		return keyUnknownPlayer
+	}
+	return byte(notationFirstToken + id)
+}
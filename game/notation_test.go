@@ -0,0 +1,125 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// notationGame returns a new game on the default board with count players.
+func notationGame(count int) *Game {
+	players := make([]*Player, count)
+	for i := range players {
+		players[i] = NewPlayer("", SymbolType(i))
+	}
+	return NewGameWithConfig(DefaultBoardWidth, DefaultBoardHeight, DefaultToWin, players)
+}
+
+func TestGameDecodeRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+	}{
+		{"empty", ""},
+		{"missing field", "3x3/3 3/3/3 a 2"},
+		{"trailing junk", "3x3/3 3/3/3 a 2 - junk"},
+		{"junk after toWin", "3x3/3x 3/3/3 a 2 -"},
+		{"missing toWin", "3x3 3/3/3 a 2 -"},
+		{"missing height", "3/3 3/3/3 a 2 -"},
+		{"zero width", "0x3/3 3/3/3 a 2 -"},
+		{"negative height", "3x-3/3 3/3/3 a 2 -"},
+		{"toWin too large", "3x3/4 3/3/3 a 2 -"},
+		{"too few ranks", "3x3/3 3/3 a 2 -"},
+		{"too many ranks", "3x3/3 3/3/3/3 a 2 -"},
+		{"short rank", "3x3/3 2/3/3 a 2 -"},
+		{"long rank", "3x3/3 a3/3/3 b 2 -"},
+		{"leading zero", "3x3/3 03/3/3 a 2 -"},
+		{"zero run", "3x3/3 a0b1/3/3 a 2 -"},
+		{"unknown player token", "3x3/3 c2/3/3 a 2 -"},
+		{"uppercase token", "3x3/3 A2/3/3 a 2 -"},
+		{"unknown side", "3x3/3 3/3/3 c 2 -"},
+		{"player count mismatch", "3x3/3 3/3/3 a 3 -"},
+		{"zero players", "3x3/3 3/3/3 a 0 -"},
+		{"unknown flag", "3x3/3 3/3/3 a 2 gravity"},
+		{"repeated flag", "3x3/3 3/3/3 a 2 deaddraw,deaddraw"},
+		{"side moved last", "3x3/3 a2/3/3 a 2 -"},
+		{"token gap", "3x3/3 aa1/3/3 b 2 -"},
+		{"two winners", "3x3/3 aaa/bbb/3 a 2 -"},
+		{"winner did not move last", "3x3/3 aaa/bb1/bb1 b 2 -"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame()
+			g.PlayMove(1, 1)
+			before := g.Encode()
+
+			err := g.Decode(tt.notation)
+			if !errors.Is(err, ErrInvalidNotation) {
+				t.Fatalf("Decode(%q) = %v, want ErrInvalidNotation", tt.notation, err)
+			}
+			if got := g.Encode(); got != before || len(g.History) != 1 {
+				t.Errorf("game changed by a failed Decode: %q, want %q", got, before)
+			}
+		})
+	}
+}
+
+func TestGameEncodeDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		players  int
+		state    GameState
+	}{
+		{name: "empty board", notation: "3x3/3 3/3/3 a 2 -", players: 2, state: PLAYING},
+		{name: "second player to move", notation: "3x3/3 a2/1b1/3 a 2 -", players: 2, state: PLAYING},
+		{name: "second player started", notation: "3x3/3 b2/3/3 a 2 -", players: 2, state: PLAYING},
+		{name: "won", notation: "3x3/3 aaa/bb1/3 a 2 -", players: 2, state: GAME_END},
+		{name: "full board", notation: "3x3/3 aba/abb/bab a 2 -", players: 2, state: GAME_END},
+		{name: "long runs", notation: "12x2/2 a10b/12 a 2 -", players: 2, state: PLAYING},
+		{name: "three players", notation: "5x4/3 a4/1b3/5/4c a 3 deaddraw", players: 3, state: PLAYING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := notationGame(tt.players)
+			if err := g.Decode(tt.notation); err != nil {
+				t.Fatal(err)
+			}
+			if got := g.Encode(); got != tt.notation {
+				t.Errorf("Encode() = %q, want %q", got, tt.notation)
+			}
+			if g.State != tt.state {
+				t.Errorf("state %v, want %v", g.State, tt.state)
+			}
+			if g.StartPosition != tt.notation {
+				t.Errorf("StartPosition = %q, want %q", g.StartPosition, tt.notation)
+			}
+		})
+	}
+}
+
+func TestGameEncodeDecodeRandomGames(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		g := NewGameWithConfig(4+rng.Intn(4), 4+rng.Intn(4), 3+rng.Intn(2), nil)
+		for moves := rng.Intn(20); moves > 0 && g.State == PLAYING; moves-- {
+			available := g.Board.AvailableMoves()
+			m := available[rng.Intn(len(available))]
+			g.PlayMove(m.X, m.Y)
+		}
+
+		notation := g.Encode()
+		decoded := NewGame()
+		if err := decoded.Decode(notation); err != nil {
+			t.Fatalf("Decode(%q): %v", notation, err)
+		}
+		if got := decoded.Encode(); got != notation {
+			t.Errorf("round trip of %q gives %q", notation, got)
+		}
+		if decoded.Board.Hash() != g.Board.Hash() {
+			t.Errorf("%q: decoded board hash differs", notation)
+		}
+	}
+}
@@ -1,32 +1,52 @@
 package game
 
-import (
-	"GoTicTacToe/assets"
-	"image/color"
+// SymbolType identifies the shape a player marks cells with.
+//
+// It is part of the player's identity (like its name); how a symbol is drawn
+// is up to the user interface.
+type SymbolType int
+
+const (
+	// CrossSymbol represents a "X" symbol.
+	CrossSymbol SymbolType = iota
+
+	// CircleSymbol represents an "O" symbol.
+	CircleSymbol
+
+	// TriangleSymbol represents a triangle symbol.
+	TriangleSymbol
+
+	// SquareSymbol represents a square symbol.
+	SquareSymbol
 )
 
 // Player represents a participant in the game.
 //
 // A player can be either human-controlled or AI-controlled.
-// The Symbol and Color are used for rendering, while Points tracks
-// the player's score across multiple rounds.
+// The Name and Symbol identify the player, while Points tracks
+// the player's score across multiple rounds. Images and colors are
+// presentation concerns and are kept by the user interface.
+//
+// ID is the player's zero-based slot in the match. It is assigned by Game and
+// identifies the player in position hashes and keys, which must not depend on
+// pointer values.
 type Player struct {
-	Symbol *assets.Symbol // Visual symbol associated with the player
-	Points int            // Score accumulated across rounds
-	Color  color.Color    // Display color used in the UI
-	Name   string         // Optional player name
-	IsAI   bool           // Indicates whether the player is AI-controlled
+	ID     int        // Zero-based player slot (assigned by Game)
+	Symbol SymbolType // Symbol the player marks cells with
+	Points int        // Score accumulated across rounds
+	Name   string     // Optional player name
+	IsAI   bool       // Indicates whether the player is AI-controlled
 }
 
 // NewPlayer creates and returns a new player instance.
 //
 // The player's score is initialized to zero.
-// The Name and IsAI fields may be set later by the caller if needed.
-func NewPlayer(sym *assets.Symbol, color color.Color) *Player {
+// The IsAI field may be set later by the caller if needed.
+func NewPlayer(name string, sym SymbolType) *Player {
 	return &Player{
+		Name:   name,
 		Symbol: sym,
 		Points: 0,
-		Color:  color,
 	}
 }
 
@@ -46,3 +66,18 @@ func (p *Player) Opponent(players []*Player) *Player {
 	}
 	return nil
 }
+
+// Next returns the player who plays after p in turn order (see
+// Game.NextPlayer), wrapping around. If p is not in players, the first player
+// is returned.
+func (p *Player) Next(players []*Player) *Player {
+	for i, candidate := range players {
+		if candidate == p {
+			return players[(i+1)%len(players)]
+		}
+	}
+	if len(players) == 0 {
+		return nil
+	}
+	return players[0]
+}
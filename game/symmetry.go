@@ -0,0 +1,225 @@
+package game
+
+// Symmetry is one of the 8 transformations of the dihedral group D4
+// (rotations and reflections of a rectangle/square).
+//
+// Rotations are clockwise as seen on screen (Y grows downwards).
+type Symmetry int
+
+const (
+	// IDENTITY leaves the board unchanged.
+	IDENTITY Symmetry = iota
+
+	// ROTATE_90 rotates the board a quarter turn clockwise.
+	ROTATE_90
+
+	// ROTATE_180 rotates the board a half turn.
+	ROTATE_180
+
+	// ROTATE_270 rotates the board a quarter turn counter-clockwise.
+	ROTATE_270
+
+	// FLIP_HORIZONTAL mirrors the board left to right.
+	FLIP_HORIZONTAL
+
+	// FLIP_VERTICAL mirrors the board top to bottom.
+	FLIP_VERTICAL
+
+	// FLIP_DIAGONAL mirrors the board along the main diagonal (transpose).
+	FLIP_DIAGONAL
+
+	// FLIP_ANTIDIAGONAL mirrors the board along the anti-diagonal.
+	FLIP_ANTIDIAGONAL
+)
+
+// squareSymmetries lists the 8 symmetries of a square board.
+var squareSymmetries = []Symmetry{
+	IDENTITY, ROTATE_90, ROTATE_180, ROTATE_270,
+	FLIP_HORIZONTAL, FLIP_VERTICAL, FLIP_DIAGONAL, FLIP_ANTIDIAGONAL,
+}
+
+// rectSymmetries lists the 4 symmetries that preserve a non-square board.
+var rectSymmetries = []Symmetry{
+	IDENTITY, ROTATE_180, FLIP_HORIZONTAL, FLIP_VERTICAL,
+}
+
+// Symmetries returns the symmetries that map a width x height board onto
+// itself: 8 for a square board, 4 otherwise. IDENTITY always comes first.
+func Symmetries(width, height int) []Symmetry {
+	if width == height {
+		return squareSymmetries
+	}
+	return rectSymmetries
+}
+
+// SwapsAxes reports whether s exchanges the width and height of a board.
+func (s Symmetry) SwapsAxes() bool {
+	switch s {
+	case ROTATE_90, ROTATE_270, FLIP_DIAGONAL, FLIP_ANTIDIAGONAL:
+		return true
+	}
+	return false
+}
+
+// Inverse returns the symmetry that undoes s.
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case ROTATE_90:
+		return ROTATE_270
+	case ROTATE_270:
+		return ROTATE_90
+	}
+	// Every other element of D4 is its own inverse.
+	return s
+}
+
+// Apply maps a move on a width x height board through s.
+//
+// The result refers to the transformed board, whose dimensions are swapped
+// when s.SwapsAxes() is true.
+func (s Symmetry) Apply(m Move, width, height int) Move {
+	x, y := m.X, m.Y
+	maxX, maxY := width-1, height-1
+
+	switch s {
+	case ROTATE_90:
+		return Move{X: maxY - y, Y: x}
+	case ROTATE_180:
+		return Move{X: maxX - x, Y: maxY - y}
+	case ROTATE_270:
+		return Move{X: y, Y: maxX - x}
+	case FLIP_HORIZONTAL:
+		return Move{X: maxX - x, Y: y}
+	case FLIP_VERTICAL:
+		return Move{X: x, Y: maxY - y}
+	case FLIP_DIAGONAL:
+		return Move{X: y, Y: x}
+	case FLIP_ANTIDIAGONAL:
+		return Move{X: maxY - y, Y: maxX - x}
+	default:
+		return m
+	}
+}
+
+// Transform returns a new board obtained by applying s to b.
+//
+// ToWin is preserved; width and height are swapped if s.SwapsAxes().
+func (b *Board) Transform(s Symmetry) *Board {
+	width, height := b.Width, b.Height
+	if s.SwapsAxes() {
+		width, height = height, width
+	}
+
+	out := NewBoard(width, height, b.ToWin)
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			if p := b.Cells[x][y]; p != nil {
+				m := s.Apply(Move{X: x, Y: y}, b.Width, b.Height)
+				out.Play(p, m.X, m.Y)
+			}
+		}
+	}
+	return out
+}
+
+// Canonical returns the canonical representative of the board among its
+// symmetric variants, and the symmetry s such that b.Transform(s) is that
+// representative.
+//
+// The representative is the variant with the smallest Key, so equivalent
+// positions always produce the same board and key. To map a move m found on
+// the canonical board back to b, use s.Inverse().Apply(m, b.Width, b.Height).
+func (b *Board) Canonical() (*Board, Symmetry) {
+	best := b.Clone()
+	bestKey := best.Key()
+	bestSym := IDENTITY
+
+	for _, s := range Symmetries(b.Width, b.Height)[1:] {
+		candidate := b.Transform(s)
+		if key := candidate.Key(); key < bestKey {
+			best, bestKey, bestSym = candidate, key, s
+		}
+	}
+	return best, bestSym
+}
+
+// CanonicalKey returns the Key of the canonical representative (see Canonical).
+func (b *Board) CanonicalKey() string {
+	canonical, _ := b.Canonical()
+	return canonical.Key()
+}
+
+// CanonicalHash returns the smallest Zobrist hash among the symmetric
+// variants of the board. Equivalent positions share the same value.
+//
+// It is cheaper than CanonicalKey (no board is allocated) but, like Hash,
+// it may collide.
+func (b *Board) CanonicalHash() uint64 {
+	var best uint64
+	for i, s := range Symmetries(b.Width, b.Height) {
+		var h uint64
+		for x := 0; x < b.Width; x++ {
+			for y := 0; y < b.Height; y++ {
+				if p := b.Cells[x][y]; p != nil {
+					m := s.Apply(Move{X: x, Y: y}, b.Width, b.Height)
+					h ^= zobristKey(m.X, m.Y, p.ID)
+				}
+			}
+		}
+		if i == 0 || h < best {
+			best = h
+		}
+	}
+	return best
+}
+
+// Stabilizer returns the symmetries that leave the board unchanged.
+// IDENTITY is always included.
+func (b *Board) Stabilizer() []Symmetry {
+	var out []Symmetry
+	for _, s := range Symmetries(b.Width, b.Height) {
+		if b.invariantUnder(s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// UniqueMoves returns the available moves, keeping only one move per class of
+// moves that are equivalent under the board's own symmetries.
+//
+// Moves keep the order of AvailableMoves, and the first move of each class is
+// kept. On an empty 3x3 board this reduces 9 moves to 3 (corner, edge, center).
+func (b *Board) UniqueMoves() []Move {
+	moves := b.AvailableMoves()
+	stabilizer := b.Stabilizer()
+	if len(stabilizer) == 1 {
+		return moves
+	}
+
+	seen := make(map[Move]bool, len(moves))
+	unique := make([]Move, 0, len(moves))
+	for _, m := range moves {
+		if seen[m] {
+			continue
+		}
+		unique = append(unique, m)
+		for _, s := range stabilizer {
+			seen[s.Apply(m, b.Width, b.Height)] = true
+		}
+	}
+	return unique
+}
+
+// invariantUnder reports whether applying s leaves every cell unchanged.
+func (b *Board) invariantUnder(s Symmetry) bool {
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			m := s.Apply(Move{X: x, Y: y}, b.Width, b.Height)
+			if b.Cells[m.X][m.Y] != b.Cells[x][y] {
+				return false
+			}
+		}
+	}
+	return true
+}
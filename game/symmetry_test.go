@@ -0,0 +1,129 @@
+package game
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// symmetryBoard decodes a board notation with the two default players.
+func symmetryBoard(t *testing.T, notation string) *Board {
+	t.Helper()
+	g := NewGame()
+	b := NewBoard(1, 1, 1)
+	if err := b.Decode(notation, g.Players); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSymmetryInverse(t *testing.T) {
+	for _, dims := range [][2]int{{3, 3}, {4, 4}, {5, 3}} {
+		width, height := dims[0], dims[1]
+		for _, s := range Symmetries(width, height) {
+			w, h := width, height
+			if s.SwapsAxes() {
+				w, h = h, w
+			}
+			for x := 0; x < width; x++ {
+				for y := 0; y < height; y++ {
+					m := Move{X: x, Y: y}
+					if got := s.Inverse().Apply(s.Apply(m, width, height), w, h); got != m {
+						t.Errorf("%dx%d: symmetry %d maps %v back to %v", width, height, s, m, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestStabilizer(t *testing.T) {
+	tests := []struct {
+		notation string
+		want     []Symmetry
+	}{
+		{"3x3/3 3/3/3", squareSymmetries},
+		{"5x3/3 5/5/5", rectSymmetries},
+		{"3x3/3 a2/3/3", []Symmetry{IDENTITY, FLIP_DIAGONAL}},
+		{"3x3/3 1a1/3/3", []Symmetry{IDENTITY, FLIP_HORIZONTAL}},
+		{"3x3/3 3/1a1/3", squareSymmetries},
+		{"3x3/3 ab1/3/3", []Symmetry{IDENTITY}},
+	}
+
+	for _, tt := range tests {
+		if got := symmetryBoard(t, tt.notation).Stabilizer(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Stabilizer(%q) = %v, want %v", tt.notation, got, tt.want)
+		}
+	}
+}
+
+func TestUniqueMoves(t *testing.T) {
+	tests := []struct {
+		notation string
+		want     []Move
+	}{
+		// Corner, edge, center.
+		{"3x3/3 3/3/3", []Move{{0, 0}, {0, 1}, {1, 1}}},
+		{"4x4/4 4/4/4/4", []Move{{0, 0}, {0, 1}, {1, 1}}},
+		{"3x4/3 3/3/3/3", []Move{{0, 0}, {0, 1}, {1, 0}, {1, 1}}},
+		{"3x3/3 3/1a1/3", []Move{{0, 0}, {0, 1}}},
+		{"3x3/3 a2/3/3", []Move{{0, 1}, {0, 2}, {1, 1}, {1, 2}, {2, 2}}},
+	}
+
+	for _, tt := range tests {
+		b := symmetryBoard(t, tt.notation)
+		got := b.UniqueMoves()
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("UniqueMoves(%q) = %v, want %v", tt.notation, got, tt.want)
+		}
+
+		// Every available move must be the image of a kept move.
+		covered := map[Move]bool{}
+		for _, m := range got {
+			for _, s := range b.Stabilizer() {
+				covered[s.Apply(m, b.Width, b.Height)] = true
+			}
+		}
+		for _, m := range b.AvailableMoves() {
+			if !covered[m] {
+				t.Errorf("UniqueMoves(%q) has no move equivalent to %v", tt.notation, m)
+			}
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		g := NewGameWithConfig(3+rng.Intn(3), 3+rng.Intn(3), 3, nil)
+		for moves := rng.Intn(8); moves > 0 && g.State == PLAYING; moves-- {
+			available := g.Board.AvailableMoves()
+			m := available[rng.Intn(len(available))]
+			g.PlayMove(m.X, m.Y)
+		}
+		b := g.Board
+
+		canonical, s := b.Canonical()
+		if got := b.Transform(s).Key(); got != canonical.Key() {
+			t.Fatalf("%q: Transform(%d) gives %q, want the canonical %q", b.Encode(), s, got, canonical.Key())
+		}
+		// Moves on the canonical board map back to the same cells of b.
+		for _, m := range canonical.AvailableMoves() {
+			back := s.Inverse().Apply(m, canonical.Width, canonical.Height)
+			if b.Cells[back.X][back.Y] != nil {
+				t.Fatalf("%q: canonical move %v maps back to the occupied %v", b.Encode(), m, back)
+			}
+		}
+
+		// Every symmetric variant has the same canonical form.
+		for _, sym := range Symmetries(b.Width, b.Height) {
+			variant := b.Transform(sym)
+			if variant.CanonicalKey() != canonical.Key() {
+				t.Errorf("%q: variant %d has another canonical key", b.Encode(), sym)
+			}
+			if variant.CanonicalHash() != b.CanonicalHash() {
+				t.Errorf("%q: variant %d has another canonical hash", b.Encode(), sym)
+			}
+		}
+	}
+}
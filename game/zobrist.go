@@ -0,0 +1,123 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Zobrist hashing constants.
+//
+// Keys are derived from a fixed seed with the SplitMix64 mixer instead of a
+// random table, so hashes are identical across runs and work for any board
+// size without pre-allocation.
+const (
+	zobristSeed      uint64 = 0x9E3779B97F4A7C15
+	zobristMulA      uint64 = 0xBF58476D1CE4E5B9
+	zobristMulB      uint64 = 0x94D049BB133111EB
+	zobristCellShift        = 8 // Bits reserved for the player ID in the key index
+)
+
+// Canonical key layout.
+const (
+	// keyEmptyCell is the character used for an empty cell in Key().
+	keyEmptyCell = '.'
+
+	// keyUnknownPlayer is used when a player ID cannot be represented by a
+	// single character (see playerLetter).
+	keyUnknownPlayer = '?'
+
+	// keyEscapeOpen and keyEscapeClose enclose, in decimal, the ID of a
+	// player beyond keyPlayerChars in Key().
+	keyEscapeOpen  = '('
+	keyEscapeClose = ')'
+
+	// keyPlayerChars maps player IDs to single characters in Key().
+	keyPlayerChars = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+	// keyHeaderCapacity is a size hint for the "WxH/K:" prefix of Key().
+	keyHeaderCapacity = 12
+)
+
+// zobristKey returns the pseudo-random key of player id standing on (x, y).
+func zobristKey(x, y, id int) uint64 {
+	// Interleave coordinates and ID into a single index; the high bits of x
+	// and y never collide for realistic board sizes.
+	idx := (uint64(uint32(x))<<32 | uint64(uint32(y))) << zobristCellShift
+	idx ^= uint64(uint8(id))
+	return splitMix64(zobristSeed + idx)
+}
+
+// splitMix64 is the finalizer of the SplitMix64 generator.
+// It turns consecutive inputs into well-distributed 64-bit values.
+func splitMix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * zobristMulA
+	z = (z ^ (z >> 27)) * zobristMulB
+	return z ^ (z >> 31)
+}
+
+// Hash returns the Zobrist hash of the current position.
+//
+// The hash is updated incrementally by Play, Undo and Clear, so reading it is
+// O(1). Two boards with the same tokens on the same cells (by player ID)
+// always share the same hash, regardless of the move order that produced them.
+func (b *Board) Hash() uint64 {
+	return b.hash
+}
+
+// RecomputeHash rebuilds the Zobrist hash from scratch.
+//
+// It is only needed when Cells has been modified directly instead of through
+// Play/Undo/Clear.
+func (b *Board) RecomputeHash() {
+	b.hash = 0
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			if p := b.Cells[x][y]; p != nil {
+				b.hash ^= zobristKey(x, y, p.ID)
+			}
+		}
+	}
+}
+
+// Key returns a canonical textual identity of the position.
+//
+// Unlike Hash, the key is collision-free: it encodes the dimensions, ToWin
+// and every cell (row by row, player IDs as base-36 digits, or in decimal
+// between parentheses beyond 35), so it can be used as a map key in
+// persisted data such as opening books or datasets.
+func (b *Board) Key() string {
+	var sb strings.Builder
+	sb.Grow(b.Width*b.Height + keyHeaderCapacity)
+
+	writeKeyHeader(&sb, b.Width, b.Height, b.ToWin)
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			writeKeyCell(&sb, b.Cells[x][y])
+		}
+	}
+	return sb.String()
+}
+
+// writeKeyHeader writes the "WxH/K:" prefix of a canonical key.
+func writeKeyHeader(sb *strings.Builder, width, height, toWin int) {
+	sb.WriteString(strconv.Itoa(width))
+	sb.WriteByte('x')
+	sb.WriteString(strconv.Itoa(height))
+	sb.WriteByte('/')
+	sb.WriteString(strconv.Itoa(toWin))
+	sb.WriteByte(':')
+}
+
+// writeKeyCell writes the encoding of a single cell in Key().
+func writeKeyCell(sb *strings.Builder, p *Player) {
+	switch {
+	case p == nil:
+		sb.WriteByte(keyEmptyCell)
+	case p.ID >= 0 && p.ID < len(keyPlayerChars):
+		sb.WriteByte(keyPlayerChars[p.ID])
+	default:
+		sb.WriteByte(keyEscapeOpen)
+		sb.WriteString(strconv.Itoa(p.ID))
+		sb.WriteByte(keyEscapeClose)
+	}
+}
@@ -0,0 +1,22 @@
+package game
+
+import "testing"
+
+func TestKeyDistinguishesPlayers(t *testing.T) {
+	keys := map[string]int{}
+	for _, id := range []int{0, 1, 35, 36, 37, 360, -1} {
+		b := NewBoard(3, 3, 3)
+		b.Play(&Player{ID: id}, 1, 1)
+		key := b.Key()
+		if other, ok := keys[key]; ok {
+			t.Errorf("players %d and %d share the key %q", other, id, key)
+		}
+		keys[key] = id
+	}
+
+	b := NewBoard(3, 3, 3)
+	b.Play(&Player{ID: 37}, 0, 0)
+	if got, want := b.Key(), "3x3/3:(37)........"; got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,258 @@
+/**
+ ******************************************************************************
+ * @file            : attempt.go
+ * @brief           : GoTicTacToe - Puzzle solving attempts
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements Attempt, a single try at solving a puzzle. After each
+ * correct move the engine answers with the defence that delays the win the
+ * longest; a move that lets the win slip away is refuted by MinimaxAI, whose
+ * time budget keeps the answer quick on any board, and ends the attempt as
+ * failed. The answer is computed by Evaluate, which leaves the attempt
+ * untouched so that it can run on a background goroutine, and played by
+ * Apply.
+ ******************************************************************************
+ */
+
+package puzzle
+
+import (
+	"GoTicTacToe/ai_models"
+	"GoTicTacToe/analysis"
+	"GoTicTacToe/game"
+	"context"
+	"errors"
+)
+
+// AttemptState represents the state of an attempt.
+type AttemptState int
+
+const (
+	// ATTEMPT_PLAYING indicates that the solver still has moves to find.
+	ATTEMPT_PLAYING AttemptState = iota
+
+	// ATTEMPT_SOLVED indicates that the solver won within the allowed moves.
+	ATTEMPT_SOLVED
+
+	// ATTEMPT_FAILED indicates that the solver played a move that does not
+	// force the win anymore.
+	ATTEMPT_FAILED
+)
+
+// ErrNotPlayable is returned by Evaluate for a move the solver cannot play
+// (attempt over, occupied or out-of-bounds cell).
+var ErrNotPlayable = errors.New("puzzle: move not playable")
+
+// Defaults of the defender refuting wrong moves: a sequential MinimaxAI, so
+// that the answer is reproducible and leaves the other cores to the user
+// interface, searching within the default move budget.
+var (
+	defaultDefender       ai_models.AIModel = ai_models.MinimaxAI{Workers: 1}
+	defaultDefenderLimits                   = ai_models.Limits{Budget: ai_models.DefaultMoveBudget}
+)
+
+// Attempt is a single try at solving a puzzle.
+type Attempt struct {
+	Puzzle    *Puzzle
+	Game      *game.Game   // Game played from the puzzle position
+	Solver    *game.Player // Player the user controls
+	State     AttemptState
+	MovesLeft int // Solver moves left to win
+
+	// Refutation is the defender's answer to a wrong move (valid once the
+	// attempt failed, if the game was not over).
+	Refutation    game.Move
+	HasRefutation bool
+
+	// Defender refutes wrong moves, searching within Limits (a sequential
+	// MinimaxAI and ai_models.DefaultMoveBudget by default).
+	Defender ai_models.AIModel
+	Limits   ai_models.Limits
+}
+
+// Reply is the outcome of a solver move, computed by Attempt.Evaluate and
+// played by Attempt.Apply.
+type Reply struct {
+	Move    game.Move // Solver move
+	Correct bool      // Whether Move still forces the win in time
+
+	// Answer is the defender's move if the game goes on: the defence that
+	// delays the win the longest, or the refutation of a wrong move.
+	Answer    game.Move
+	HasAnswer bool
+}
+
+// NewAttempt starts an attempt on p from its start position.
+func NewAttempt(p *Puzzle) (*Attempt, error) {
+	g, err := p.NewGame()
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Solutions) == 0 {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Attempt{
+		Puzzle:    p,
+		Game:      g,
+		Solver:    g.Current,
+		State:     ATTEMPT_PLAYING,
+		MovesLeft: p.WinIn,
+		Defender:  defaultDefender,
+		Limits:    defaultDefenderLimits,
+	}, nil
+}
+
+// CanPlay reports whether the solver may play at (x, y) now.
+func (a *Attempt) CanPlay(x, y int) bool {
+	g := a.Game
+	if a.State != ATTEMPT_PLAYING || g.State != game.PLAYING || g.Current != a.Solver {
+		return false
+	}
+	b := g.Board
+	return x >= 0 && y >= 0 && x < b.Width && y < b.Height && b.Cells[x][y] == nil
+}
+
+// Play plays the solver's move at (x, y) and the engine's reply, computed on
+// the calling goroutine (see Evaluate and Apply).
+//
+// It returns false if the move is not playable or ctx is done first.
+func (a *Attempt) Play(ctx context.Context, x, y int) bool {
+	r, err := a.Evaluate(ctx, x, y)
+	return err == nil && a.Apply(r)
+}
+
+// Evaluate checks the solver's move at (x, y) and computes the defender's
+// answer, without modifying the attempt.
+//
+// It may take a while (forced-win searches, and a Defender search within
+// Limits after a wrong move), so it is meant to run on its own goroutine
+// while the attempt is left unchanged. It returns ErrNotPlayable if the move
+// is not playable, and ctx.Err() if ctx is cancelled first.
+func (a *Attempt) Evaluate(ctx context.Context, x, y int) (Reply, error) {
+	if !a.CanPlay(x, y) {
+		return Reply{}, ErrNotPlayable
+	}
+
+	// Decide before playing: does the move still force a win in time?
+	r := Reply{Move: game.Move{X: x, Y: y}}
+	r.Correct = a.forcesWin(r.Move)
+	if err := ctx.Err(); err != nil {
+		return Reply{}, err
+	}
+
+	board := a.Game.Board.Clone()
+	board.Play(a.Solver, x, y)
+	if board.CheckWin() != nil || board.CheckDraw() {
+		return r, nil
+	}
+
+	defender := a.Solver.Opponent(a.Game.Players)
+	var err error
+	if r.Correct {
+		r.Answer, r.HasAnswer, err = a.bestDefence(ctx, board, defender)
+	} else {
+		r.Answer, r.HasAnswer, err = a.refutation(ctx, board, defender)
+	}
+	if err != nil {
+		return Reply{}, err
+	}
+	return r, nil
+}
+
+// Apply plays a reply computed by Evaluate: the solver's move, then the
+// defender's answer if the game goes on.
+//
+// It returns false if the solver's move is not playable anymore.
+func (a *Attempt) Apply(r Reply) bool {
+	g := a.Game
+	if !a.CanPlay(r.Move.X, r.Move.Y) || !g.PlayMove(r.Move.X, r.Move.Y) {
+		return false
+	}
+	a.MovesLeft--
+
+	if g.State == game.GAME_END {
+		if g.Winner == a.Solver {
+			a.State = ATTEMPT_SOLVED
+		} else {
+			a.State = ATTEMPT_FAILED
+		}
+		return true
+	}
+
+	if !r.Correct {
+		a.State = ATTEMPT_FAILED
+	}
+	if r.HasAnswer && g.PlayMove(r.Answer.X, r.Answer.Y) && !r.Correct {
+		a.Refutation = r.Answer
+		a.HasRefutation = true
+	}
+	return true
+}
+
+// forcesWin reports whether the solver playing m still wins in MovesLeft.
+func (a *Attempt) forcesWin(m game.Move) bool {
+	g := a.Game
+	if a.MovesLeft == a.Puzzle.WinIn {
+		return a.Puzzle.IsSolution(m)
+	}
+
+	for _, w := range analysis.WinningMoves(g.Board, g.Players, a.Solver, pliesFor(a.MovesLeft)) {
+		if w == m {
+			return true
+		}
+	}
+	return false
+}
+
+// refutation returns the Defender's answer to a wrong move on board, where
+// defender is to move. A failed search leaves the move unanswered.
+func (a *Attempt) refutation(ctx context.Context, board *game.Board, defender *game.Player) (game.Move, bool, error) {
+	model := a.Defender
+	if model == nil {
+		model = defaultDefender
+	}
+
+	m, _, err := ai_models.Adapt(model).Search(ctx, board, defender, a.Game.Players, a.Limits)
+	if err != nil {
+		return game.Move{}, false, ctx.Err()
+	}
+	return m, true, nil
+}
+
+// bestDefence returns the move of defender on board that delays the solver's
+// win the longest, preferring moves that escape it within the solver's
+// remaining moves altogether.
+func (a *Attempt) bestDefence(ctx context.Context, board *game.Board, defender *game.Player) (game.Move, bool, error) {
+	limit := pliesFor(a.MovesLeft - 1)
+
+	var best game.Move
+	bestPlies := -1
+	for _, m := range board.AvailableMoves() {
+		if err := ctx.Err(); err != nil {
+			return game.Move{}, false, err
+		}
+
+		board.Play(defender, m.X, m.Y)
+		// A reply that wins or escapes the forced win is the strongest.
+		plies := limit + 1
+		if board.CheckWin() == nil && !board.CheckDraw() {
+			if _, n, ok := analysis.ForcedWin(board, a.Game.Players, a.Solver, limit); ok {
+				plies = n
+			}
+		}
+		board.Undo(m.X, m.Y)
+
+		if plies > bestPlies {
+			best, bestPlies = m, plies
+		}
+	}
+	return best, bestPlies >= 0, nil
+}
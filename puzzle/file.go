@@ -0,0 +1,224 @@
+/**
+ ******************************************************************************
+ * @file            : file.go
+ * @brief           : GoTicTacToe - Puzzle file format
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the puzzle file format. Puzzles are blocks of
+ * "key: value" lines separated by blank lines; lines starting with '#' are
+ * comments:
+ *
+ *   title: Corner trap
+ *   position: 3x3/3 a2/1b1/3 a 2 -
+ *   win: 2
+ *   hint: Two threats are better than one.
+ *   solution: c1
+ *   rating: 2
+ *
+ * position and win are required. solution is written for reference and,
+ * when present, must list key moves. rating is the difficulty given by the
+ * generator, from 1 to 5.
+ ******************************************************************************
+ */
+
+package puzzle
+
+import (
+	"GoTicTacToe/game"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Puzzle file keys.
+const (
+	keyTitle    = "title"
+	keyPosition = "position"
+	keyWin      = "win"
+	keyHint     = "hint"
+	keySolution = "solution"
+	keyRating   = "rating"
+
+	commentPrefix = "#"
+	keySeparator  = ":"
+)
+
+// Read parses and validates every puzzle contained in r.
+//
+// Errors report the line where the faulty puzzle starts.
+func Read(r io.Reader) ([]*Puzzle, error) {
+	var puzzles []*Puzzle
+	var cur *block
+
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		p, err := cur.puzzle()
+		if err != nil {
+			return fmt.Errorf("puzzle: line %d: %w", cur.line, err)
+		}
+		puzzles = append(puzzles, p)
+		cur = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(text, commentPrefix):
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, keySeparator)
+		if !ok {
+			return nil, fmt.Errorf("puzzle: line %d: expected \"key: value\", got %q", line, text)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if cur == nil {
+			cur = &block{fields: map[string]string{}, line: line}
+		}
+		if _, dup := cur.fields[key]; dup {
+			return nil, fmt.Errorf("puzzle: line %d: duplicate key %q", line, key)
+		}
+		cur.fields[key] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return puzzles, nil
+}
+
+// ReadFile parses and validates every puzzle contained in the file at path.
+func ReadFile(path string) ([]*Puzzle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Write writes the puzzles to w, separated by blank lines.
+func Write(w io.Writer, puzzles ...*Puzzle) error {
+	bw := bufio.NewWriter(w)
+	for i, p := range puzzles {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		if p.Title != "" {
+			writeField(bw, keyTitle, p.Title)
+		}
+		writeField(bw, keyPosition, p.Position)
+		writeField(bw, keyWin, strconv.Itoa(p.WinIn))
+		if p.Hint != "" {
+			writeField(bw, keyHint, p.Hint)
+		}
+		if len(p.Solutions) > 0 {
+			moves := make([]string, len(p.Solutions))
+			for j, m := range p.Solutions {
+				moves[j] = m.String()
+			}
+			writeField(bw, keySolution, strings.Join(moves, " "))
+		}
+		if p.Rating > 0 {
+			writeField(bw, keyRating, strconv.Itoa(p.Rating))
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteFile writes the puzzles to the file at path, replacing it.
+func WriteFile(path string, puzzles ...*Puzzle) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, puzzles...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeField writes a single "key: value" line.
+func writeField(w *bufio.Writer, key, value string) {
+	w.WriteString(key + keySeparator + " " + value + "\n")
+}
+
+// block accumulates the fields of the puzzle being read.
+type block struct {
+	fields map[string]string
+	line   int // Line where the puzzle starts
+}
+
+// puzzle builds and validates the puzzle described by the block.
+func (b *block) puzzle() (*Puzzle, error) {
+	for key := range b.fields {
+		switch key {
+		case keyTitle, keyPosition, keyWin, keyHint, keySolution, keyRating:
+		default:
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+
+	p := &Puzzle{
+		Title:    b.fields[keyTitle],
+		Position: b.fields[keyPosition],
+		Hint:     b.fields[keyHint],
+	}
+	if p.Position == "" {
+		return nil, fmt.Errorf("missing %q", keyPosition)
+	}
+
+	winIn, err := strconv.Atoi(b.fields[keyWin])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q value %q", keyWin, b.fields[keyWin])
+	}
+	p.WinIn = winIn
+
+	if r, ok := b.fields[keyRating]; ok {
+		rating, err := strconv.Atoi(r)
+		if err != nil || rating < minRating || rating > maxRating {
+			return nil, fmt.Errorf("invalid %q value %q", keyRating, r)
+		}
+		p.Rating = rating
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	// A listed solution must agree with the search.
+	for _, s := range strings.Fields(b.fields[keySolution]) {
+		m, err := game.ParseMove(s)
+		if err != nil {
+			return nil, fmt.Errorf("solution: %v", err)
+		}
+		if !p.IsSolution(m) {
+			return nil, fmt.Errorf("%w: %s does not force a win in %d", ErrInvalidPuzzle, m, p.WinIn)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,282 @@
+/**
+ ******************************************************************************
+ * @file            : generate.go
+ * @brief           : GoTicTacToe - Puzzle generator
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the puzzle generator. It plays semi-random games
+ * (blocking obvious threats, mostly playing next to existing tokens) and
+ * keeps the positions where the side to move has a single key move forcing
+ * a win. Puzzles are rated from the depth of the win, the number of
+ * plausible defences and the number of tempting decoys.
+ *
+ * The generator is deterministic for a given seed; DailySeed derives the
+ * seed from a date so that everyone gets the same daily puzzle.
+ ******************************************************************************
+ */
+
+package puzzle
+
+import (
+	"GoTicTacToe/analysis"
+	"GoTicTacToe/game"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// Generator defaults and tuning.
+const (
+	// defaultMaxGames bounds the number of games played by Generate.
+	defaultMaxGames = 2000
+
+	// minStonesBeforePuzzle skips the opening, where puzzles are trivial.
+	minStonesBeforePuzzle = 3
+
+	// blockProbability is the chance that a random player blocks an
+	// immediate threat of the opponent.
+	blockProbability = 0.9
+
+	// nearbyProbability is the chance to play next to an existing token
+	// rather than anywhere on the board.
+	nearbyProbability = 0.75
+
+	// Rating scale.
+	minRating = 1
+	maxRating = 5
+
+	// ratingDefences and ratingDecoys are the counts above which a puzzle
+	// earns an extra rating point.
+	ratingDefences = 3
+	ratingDecoys   = 2
+
+	// dailyDateLayout formats dates in daily seeds and titles.
+	dailyDateLayout = "2006-01-02"
+)
+
+// ErrNoPuzzle is returned when the generator found fewer puzzles than asked.
+var ErrNoPuzzle = errors.New("puzzle: no puzzle found")
+
+// GeneratorConfig describes the puzzles to generate.
+type GeneratorConfig struct {
+	Width  int // Number of columns
+	Height int // Number of rows
+	ToWin  int // Required aligned symbols to win
+
+	MinWinIn int // Fewest solver moves of a puzzle (at least 1)
+	MaxWinIn int // Most solver moves of a puzzle
+
+	Count    int   // Number of puzzles to generate
+	Seed     int64 // Random seed (see DailySeed)
+	MaxGames int   // Number of games to try before giving up (0 = default)
+}
+
+// Generate returns up to cfg.Count distinct puzzles, in the order found.
+//
+// It returns ErrNoPuzzle (along with the puzzles found) if fewer puzzles
+// were found within cfg.MaxGames games.
+func Generate(cfg GeneratorConfig) ([]*Puzzle, error) {
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.ToWin < 1 {
+		return nil, fmt.Errorf("%w: board %dx%d/%d", ErrInvalidPuzzle, cfg.Width, cfg.Height, cfg.ToWin)
+	}
+	minWinIn := max(cfg.MinWinIn, 1)
+	maxWinIn := max(cfg.MaxWinIn, minWinIn)
+	maxGames := cfg.MaxGames
+	if maxGames <= 0 {
+		maxGames = defaultMaxGames
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	seen := map[string]bool{}
+	var puzzles []*Puzzle
+
+	for games := 0; games < maxGames && len(puzzles) < cfg.Count; games++ {
+		g := game.NewGameWithConfig(cfg.Width, cfg.Height, cfg.ToWin, nil)
+
+		for g.State == game.PLAYING && len(puzzles) < cfg.Count {
+			if len(g.History) >= minStonesBeforePuzzle {
+				if p := candidate(g, minWinIn, maxWinIn); p != nil {
+					key := g.Board.CanonicalKey() + " " + string(rune('a'+g.Current.ID))
+					if !seen[key] {
+						seen[key] = true
+						puzzles = append(puzzles, p)
+					}
+					break // The rest of this game follows a won position.
+				}
+			}
+
+			m := semiRandomMove(g, rng)
+			g.PlayMove(m.X, m.Y)
+		}
+	}
+
+	if len(puzzles) < cfg.Count {
+		return puzzles, fmt.Errorf("%w: %d of %d after %d games", ErrNoPuzzle, len(puzzles), cfg.Count, maxGames)
+	}
+	return puzzles, nil
+}
+
+// DailySeed returns the generator seed of the daily puzzle of date (in UTC).
+func DailySeed(date time.Time) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(date.UTC().Format(dailyDateLayout)))
+	return int64(h.Sum64())
+}
+
+// Daily returns the daily puzzle of date for the board configuration of cfg
+// (its Seed and Count are ignored).
+func Daily(cfg GeneratorConfig, date time.Time) (*Puzzle, error) {
+	cfg.Seed = DailySeed(date)
+	cfg.Count = 1
+
+	puzzles, err := Generate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p := puzzles[0]
+	p.Title = "Daily puzzle " + date.UTC().Format(dailyDateLayout)
+	return p, nil
+}
+
+// candidate returns the puzzle of the current position if the side to move
+// has a single key move winning in MinWinIn..MaxWinIn moves, or nil.
+func candidate(g *game.Game, minWinIn, maxWinIn int) *Puzzle {
+	me := g.Current
+
+	_, plies, ok := analysis.ForcedWin(g.Board, g.Players, me, pliesFor(maxWinIn))
+	if !ok {
+		return nil
+	}
+	winIn := (plies-1)/puzzlePlayers + 1
+	if winIn < minWinIn {
+		return nil
+	}
+
+	p := &Puzzle{Position: g.Encode(), WinIn: winIn}
+	p.Solutions = analysis.WinningMoves(g.Board, g.Players, me, p.Plies())
+	if len(p.Solutions) != 1 {
+		return nil
+	}
+	p.Rating = rate(g, p)
+	return p
+}
+
+// rate computes the difficulty of p, from minRating to maxRating.
+//
+// The base rating is the number of moves to find. A point is added when the
+// defender has many plausible replies along the main line (the solver must
+// have an answer ready for each of them), and another when several moves
+// create a double threat without being the key move.
+func rate(g *game.Game, p *Puzzle) int {
+	me := g.Current
+	board := g.Board.Clone()
+
+	decoys := 0
+	for _, m := range analysis.Forks(board, me) {
+		if !p.IsSolution(m) {
+			decoys++
+		}
+	}
+
+	// Follow the main line: key move, then a plausible reply that delays
+	// the win the longest.
+	defences := 0
+	opp := me.Opponent(g.Players)
+	m := p.Solutions[0]
+	for movesLeft := p.WinIn - 1; movesLeft > 0; movesLeft-- {
+		board.Play(me, m.X, m.Y)
+
+		replies := plausibleDefences(board, me)
+		var reply game.Move
+		longest := -1
+		for _, d := range replies {
+			board.Play(opp, d.X, d.Y)
+			if _, plies, ok := analysis.ForcedWin(board, g.Players, me, pliesFor(movesLeft)); ok && plies > longest {
+				reply, longest = d, plies
+			}
+			board.Undo(d.X, d.Y)
+		}
+		if longest < 0 {
+			break
+		}
+		defences += len(replies)
+
+		board.Play(opp, reply.X, reply.Y)
+		next, _, ok := analysis.ForcedWin(board, g.Players, me, pliesFor(movesLeft))
+		if !ok {
+			break
+		}
+		m = next
+	}
+
+	rating := p.WinIn
+	if defences >= ratingDefences {
+		rating++
+	}
+	if decoys >= ratingDecoys {
+		rating++
+	}
+	return min(max(rating, minRating), maxRating)
+}
+
+// plausibleDefences returns the replies a reasonable defender considers
+// against attacker: blocking an immediate win if there is one, otherwise
+// taking a cell of a window the attacker has started.
+func plausibleDefences(board *game.Board, attacker *game.Player) []game.Move {
+	if blocks := analysis.ImmediateWins(board, attacker); len(blocks) > 0 {
+		return blocks
+	}
+
+	var moves []game.Move
+	seen := map[game.Move]bool{}
+	for _, w := range analysis.OpenWindows(board, attacker) {
+		if w.Stones == 0 {
+			continue
+		}
+		for _, c := range w.Empty {
+			if !seen[c] {
+				seen[c] = true
+				moves = append(moves, c)
+			}
+		}
+	}
+	return moves
+}
+
+// semiRandomMove picks a plausible move: it blocks an immediate threat of
+// the next player most of the time, and otherwise prefers cells next to
+// existing tokens.
+func semiRandomMove(g *game.Game, rng *rand.Rand) game.Move {
+	board := g.Board
+	next := g.Current.Opponent(g.Players)
+
+	if blocks := analysis.ImmediateWins(board, next); len(blocks) > 0 && rng.Float64() < blockProbability {
+		return blocks[rng.Intn(len(blocks))]
+	}
+
+	moves := board.AvailableMoves()
+	if rng.Float64() < nearbyProbability {
+		if nearby := nearbyMoves(board, moves); len(nearby) > 0 {
+			moves = nearby
+		}
+	}
+	return moves[rng.Intn(len(moves))]
+}
+
+// nearbyMoves returns the moves adjacent (including diagonally) to a token.
+func nearbyMoves(board *game.Board, moves []game.Move) []game.Move {
+	var nearby []game.Move
+	for _, m := range moves {
+		if board.HasNeighbour(m.X, m.Y) {
+			nearby = append(nearby, m)
+		}
+	}
+	return nearby
+}
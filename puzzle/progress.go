@@ -0,0 +1,126 @@
+/**
+ ******************************************************************************
+ * @file            : progress.go
+ * @brief           : GoTicTacToe - Puzzle progress tracking
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements Progress, the per-puzzle results of a user, and its
+ * text file format (one puzzle per line: attempts, solved flag, key).
+ ******************************************************************************
+ */
+
+package puzzle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Progress file constants.
+const (
+	// progressFieldCount is the number of fields of a progress line.
+	progressFieldCount = 3
+
+	// progressFileMode is the permission of a created progress file.
+	progressFileMode = 0o644
+)
+
+// Result is the progress on a single puzzle.
+type Result struct {
+	Attempts int  // Finished attempts
+	Solved   bool // Whether an attempt succeeded
+}
+
+// Progress maps puzzle keys (see Puzzle.Key) to results.
+type Progress map[string]Result
+
+// Record counts a finished attempt on p.
+func (pr Progress) Record(p *Puzzle, solved bool) {
+	r := pr[p.Key()]
+	r.Attempts++
+	r.Solved = r.Solved || solved
+	pr[p.Key()] = r
+}
+
+// Of returns the result of p (zero if never attempted).
+func (pr Progress) Of(p *Puzzle) Result {
+	return pr[p.Key()]
+}
+
+// SolvedCount returns how many of puzzles are solved.
+func (pr Progress) SolvedCount(puzzles []*Puzzle) int {
+	count := 0
+	for _, p := range puzzles {
+		if pr.Of(p).Solved {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadProgress reads the progress file at path. A missing file gives an
+// empty progress.
+func LoadProgress(path string) (Progress, error) {
+	pr := Progress{}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pr, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := strings.SplitN(text, " ", progressFieldCount)
+		if len(fields) != progressFieldCount {
+			return nil, fmt.Errorf("puzzle progress: line %d: malformed", line)
+		}
+		attempts, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("puzzle progress: line %d: attempts: %v", line, err)
+		}
+		solved, err := strconv.ParseBool(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("puzzle progress: line %d: solved: %v", line, err)
+		}
+		pr[fields[2]] = Result{Attempts: attempts, Solved: solved}
+	}
+	return pr, scanner.Err()
+}
+
+// Save writes the progress to the file at path, replacing it.
+func (pr Progress) Save(path string) error {
+	keys := make([]string, 0, len(pr))
+	for key := range pr {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		r := pr[key]
+		fmt.Fprintf(&sb, "%d %t %s\n", r.Attempts, r.Solved, key)
+	}
+	return os.WriteFile(path, []byte(sb.String()), progressFileMode)
+}
@@ -0,0 +1,126 @@
+/**
+ ******************************************************************************
+ * @file            : puzzle.go
+ * @brief           : GoTicTacToe - Puzzle definition and validation
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file defines Puzzle and its validation: the side to move must have a
+ * forced win in exactly WinIn of its own moves against any defence. The key
+ * moves (first moves of a forced win) are computed by the analysis package.
+ ******************************************************************************
+ */
+
+// Package puzzle implements "win in N" problems: their file format, their
+// validation by search, solving attempts and per-puzzle progress.
+package puzzle
+
+import (
+	"GoTicTacToe/analysis"
+	"GoTicTacToe/game"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// puzzlePlayers is the number of players supported by puzzles: the solver
+// and a single defender.
+const puzzlePlayers = 2
+
+// ErrInvalidPuzzle is wrapped by every validation error.
+var ErrInvalidPuzzle = errors.New("invalid puzzle")
+
+// Puzzle is a "win in N" problem.
+type Puzzle struct {
+	Title    string // Optional display title
+	Position string // Start position in game notation (includes the side to move)
+	WinIn    int    // Number of moves of the side to move needed to win
+	Hint     string // Optional hint shown on request
+	Rating   int    // Difficulty from 1 (easy) to 5, 0 if unrated
+
+	// Solutions lists the key moves: the first moves that force a win in
+	// WinIn moves. It is filled by Validate.
+	Solutions []game.Move
+}
+
+// Key identifies the puzzle (e.g. for progress tracking): its position and
+// number of moves.
+func (p *Puzzle) Key() string {
+	return fmt.Sprintf("%s %d", p.Position, p.WinIn)
+}
+
+// Name returns the title, or a generic name when the puzzle has none.
+func (p *Puzzle) Name() string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return fmt.Sprintf("Win in %d", p.WinIn)
+}
+
+// Plies returns the maximum number of plies of the solution, counting the
+// solver's WinIn moves and the defender's replies in between.
+func (p *Puzzle) Plies() int {
+	return pliesFor(p.WinIn)
+}
+
+// NewGame returns a game set up on the puzzle position, with the default
+// players (one per player of the position).
+func (p *Puzzle) NewGame() (*game.Game, error) {
+	fields := strings.Fields(p.Position)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("%w: position %q: %v", ErrInvalidPuzzle, p.Position, game.ErrInvalidNotation)
+	}
+	count, err := strconv.Atoi(fields[3])
+	if err != nil || count != puzzlePlayers {
+		return nil, fmt.Errorf("%w: puzzles need %d players, position has %q", ErrInvalidPuzzle, puzzlePlayers, fields[3])
+	}
+
+	g := game.NewGame()
+	if err := g.Decode(p.Position); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPuzzle, err)
+	}
+	return g, nil
+}
+
+// Validate checks that the side to move can force a win in WinIn moves but
+// not in fewer, and fills Solutions.
+func (p *Puzzle) Validate() error {
+	if p.WinIn < 1 {
+		return fmt.Errorf("%w: win in %d", ErrInvalidPuzzle, p.WinIn)
+	}
+
+	g, err := p.NewGame()
+	if err != nil {
+		return err
+	}
+	if g.State != game.PLAYING {
+		return fmt.Errorf("%w: position is already over", ErrInvalidPuzzle)
+	}
+
+	solutions := analysis.WinningMoves(g.Board, g.Players, g.Current, p.Plies())
+	if len(solutions) == 0 {
+		return fmt.Errorf("%w: no forced win in %d", ErrInvalidPuzzle, p.WinIn)
+	}
+	if p.WinIn > 1 && analysis.HasForcedWin(g.Board, g.Players, g.Current, pliesFor(p.WinIn-1)) {
+		return fmt.Errorf("%w: a faster win exists than in %d", ErrInvalidPuzzle, p.WinIn)
+	}
+
+	p.Solutions = solutions
+	return nil
+}
+
+// IsSolution reports whether m is one of the key moves (see Validate).
+func (p *Puzzle) IsSolution(m game.Move) bool {
+	return slices.Contains(p.Solutions, m)
+}
+
+// pliesFor returns the plies needed by a two-player win in n moves.
+func pliesFor(n int) int {
+	return (n-1)*puzzlePlayers + 1
+}
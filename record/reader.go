@@ -0,0 +1,362 @@
+/**
+ ******************************************************************************
+ * @file            : reader.go
+ * @brief           : GoTicTacToe - Game record parser
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the parser of the record text format, as well as
+ * small helpers to load and append record files.
+ ******************************************************************************
+ */
+
+package record
+
+import (
+	"GoTicTacToe/game"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// File permissions used when creating a record file.
+const recordFileMode = 0o644
+
+// Read parses every record contained in r.
+//
+// Errors report the line where parsing failed.
+func Read(r io.Reader) ([]*Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: string(data), line: 1}
+	return p.parseAll()
+}
+
+// ReadFile parses every record contained in the file at path.
+func ReadFile(path string) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// AppendFile appends the records to the file at path, creating it if needed.
+func AppendFile(path string, records ...*Record) error {
+	info, err := os.Stat(path)
+	nonEmpty := err == nil && info.Size() > 0
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, recordFileMode)
+	if err != nil {
+		return err
+	}
+
+	if nonEmpty {
+		if _, err := io.WriteString(f, "\n"); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := Write(f, records...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// parser is a small hand-written scanner over the whole input.
+type parser struct {
+	src  string
+	pos  int
+	line int
+}
+
+// pending accumulates the parts of the record being read.
+type pending struct {
+	tags  map[string]string
+	order []string
+	rec   *Record
+	line  int // Line where the record started
+}
+
+// errorf returns an error annotated with the current line.
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("record: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// parseAll reads records until the end of input.
+func (p *parser) parseAll() ([]*Record, error) {
+	var records []*Record
+	var cur *pending
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			break
+		}
+
+		switch p.src[p.pos] {
+		case '[':
+			if cur != nil && cur.rec != nil {
+				return nil, p.errorf("tag found inside movetext (missing result?)")
+			}
+			if cur == nil {
+				cur = &pending{tags: map[string]string{}, line: p.line}
+			}
+			name, value, err := p.parseTag()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := cur.tags[name]; dup {
+				return nil, p.errorf("duplicate tag %q", name)
+			}
+			cur.tags[name] = value
+			cur.order = append(cur.order, name)
+
+		case '{':
+			if cur == nil {
+				return nil, p.errorf("comment before any header")
+			}
+			if err := p.ensureRecord(cur); err != nil {
+				return nil, err
+			}
+			text, err := p.parseComment()
+			if err != nil {
+				return nil, err
+			}
+			if n := len(cur.rec.Moves); n > 0 {
+				cur.rec.Moves[n-1].Comment = joinComment(cur.rec.Moves[n-1].Comment, text)
+			} else {
+				cur.rec.Comment = joinComment(cur.rec.Comment, text)
+			}
+
+		default:
+			if cur == nil {
+				return nil, p.errorf("movetext before any header")
+			}
+			if err := p.ensureRecord(cur); err != nil {
+				return nil, err
+			}
+			done, err := p.parseToken(cur.rec)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				records = append(records, cur.rec)
+				cur = nil
+			}
+		}
+	}
+
+	if cur != nil {
+		return nil, fmt.Errorf("record: game starting at line %d has no result", cur.line)
+	}
+	return records, nil
+}
+
+// ensureRecord builds the Record from the header once movetext starts.
+func (p *parser) ensureRecord(cur *pending) error {
+	if cur.rec != nil {
+		return nil
+	}
+	rec, err := recordFromTags(cur.tags)
+	if err != nil {
+		return fmt.Errorf("record: game starting at line %d: %w", cur.line, err)
+	}
+	cur.rec = rec
+	return nil
+}
+
+// skipSpace advances past whitespace, counting lines.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\n':
+			p.line++
+		case ' ', '\t', '\r':
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+// parseTag reads a `[Name "value"]` tag pair.
+func (p *parser) parseTag() (string, string, error) {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end < 0 {
+		end = len(p.src) - p.pos
+	}
+	raw := strings.TrimSpace(p.src[p.pos : p.pos+end])
+	p.pos += end
+
+	if !strings.HasSuffix(raw, "]") {
+		return "", "", p.errorf("tag %q is not closed by ']'", raw)
+	}
+	body := strings.TrimSpace(raw[1 : len(raw)-1])
+
+	name, quoted, ok := strings.Cut(body, " ")
+	if !ok || name == "" {
+		return "", "", p.errorf("tag %q: expected [Name \"value\"]", raw)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(quoted))
+	if err != nil {
+		return "", "", p.errorf("tag %q: value must be a quoted string", name)
+	}
+	return name, value, nil
+}
+
+// parseComment reads a brace-delimited comment (possibly multi-line).
+func (p *parser) parseComment() (string, error) {
+	end := strings.Index(p.src[p.pos:], commentClose)
+	if end < 0 {
+		return "", p.errorf("unterminated comment")
+	}
+	text := p.src[p.pos+len(commentOpen) : p.pos+end]
+	p.line += strings.Count(text, "\n")
+	p.pos += end + len(commentClose)
+	return strings.TrimSpace(text), nil
+}
+
+// parseToken reads one movetext token and applies it to rec.
+// It returns true when the token was the result that ends the record.
+func (p *parser) parseToken(rec *Record) (bool, error) {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\r\n{[", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	tok := p.src[start:p.pos]
+
+	// Move number: must match the round of the next move.
+	if strings.HasSuffix(tok, ".") {
+		n, err := strconv.Atoi(strings.TrimSuffix(tok, "."))
+		perRound := max(len(rec.Players), 1)
+		if err != nil {
+			return false, p.errorf("invalid move number %q", tok)
+		}
+		if len(rec.Moves)%perRound != 0 || n != len(rec.Moves)/perRound+1 {
+			return false, p.errorf("unexpected move number %q", tok)
+		}
+		return false, nil
+	}
+
+	if isResult(tok, len(rec.Players)) {
+		if tok != rec.Result {
+			return false, p.errorf("result %q does not match Result tag %q", tok, rec.Result)
+		}
+		return true, nil
+	}
+
+	mv, err := game.ParseMove(tok)
+	if err != nil {
+		return false, p.errorf("%v", err)
+	}
+	if mv.X >= rec.Width || mv.Y >= rec.Height {
+		return false, p.errorf("move %q is outside the %dx%d board", tok, rec.Width, rec.Height)
+	}
+	rec.Moves = append(rec.Moves, MoveEntry{Move: mv})
+	return false, nil
+}
+
+// recordFromTags builds a Record header from parsed tags.
+func recordFromTags(tags map[string]string) (*Record, error) {
+	rec := &Record{Extra: map[string]string{}}
+
+	board, ok := tags[tagBoard]
+	if !ok {
+		return nil, fmt.Errorf("missing %s tag", tagBoard)
+	}
+	if _, err := fmt.Sscanf(board, "%dx%d/%d", &rec.Width, &rec.Height, &rec.ToWin); err != nil ||
+		rec.Width < 1 || rec.Height < 1 || rec.ToWin < 1 {
+		return nil, fmt.Errorf("invalid %s tag %q: expected <width>x<height>/<toWin>", tagBoard, board)
+	}
+
+	if date, ok := tags[tagDate]; ok && date != unknownDate {
+		t, err := time.Parse(dateLayout, date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s tag %q: expected YYYY.MM.DD", tagDate, date)
+		}
+		rec.Date = t
+	}
+
+	rec.TimeControl = NoTimeControl
+	if tc, ok := tags[tagTimeControl]; ok {
+		rec.TimeControl = tc
+	}
+	rec.Position = tags[tagPosition]
+
+	// Players are numbered from 1 without gaps.
+	for i := 1; ; i++ {
+		name, ok := tags[tagPlayerPrefix+strconv.Itoa(i)]
+		if !ok {
+			break
+		}
+		model := tags[tagPlayerPrefix+strconv.Itoa(i)+tagModelSuffix]
+		rec.Players = append(rec.Players, PlayerInfo{Name: name, Model: model})
+	}
+	if len(rec.Players) == 0 {
+		return nil, fmt.Errorf("missing %s1 tag", tagPlayerPrefix)
+	}
+
+	result, ok := tags[tagResult]
+	if !ok {
+		return nil, fmt.Errorf("missing %s tag", tagResult)
+	}
+	if !isResult(result, len(rec.Players)) {
+		return nil, fmt.Errorf("invalid %s tag %q", tagResult, result)
+	}
+	rec.Result = result
+
+	for name, value := range tags {
+		if !isKnownTag(name, len(rec.Players)) {
+			rec.Extra[name] = value
+		}
+	}
+	if len(rec.Extra) == 0 {
+		rec.Extra = nil
+	}
+	return rec, nil
+}
+
+// isKnownTag reports whether a tag is stored in a dedicated Record field.
+func isKnownTag(name string, playerCount int) bool {
+	switch name {
+	case tagBoard, tagDate, tagResult, tagTimeControl, tagPosition:
+		return true
+	}
+	for i := 1; i <= playerCount; i++ {
+		player := tagPlayerPrefix + strconv.Itoa(i)
+		if name == player || name == player+tagModelSuffix {
+			return true
+		}
+	}
+	return false
+}
+
+// isResult reports whether tok is a valid result for a game with the given
+// number of players.
+func isResult(tok string, playerCount int) bool {
+	if tok == ResultOngoing || tok == ResultDraw {
+		return true
+	}
+	return len(tok) == 1 && tok[0] >= firstResultLetter && int(tok[0]-firstResultLetter) < playerCount
+}
+
+// joinComment appends a comment to an existing one.
+func joinComment(existing, text string) string {
+	if existing == "" {
+		return text
+	}
+	return existing + " " + text
+}
@@ -0,0 +1,184 @@
+/**
+ ******************************************************************************
+ * @file            : record.go
+ * @brief           : GoTicTacToe - Game record definition and replay
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file defines Record, the in-memory representation of a recorded
+ * match, and helpers to build a record from a running game and to replay it.
+ *
+ * The text format is close to PGN in chess. A record is a header of tag
+ * pairs followed by the move list in coordinate notation (see game.Move).
+ * Move numbers count full rounds, comments are written in braces after the
+ * move they refer to, and the list ends with the result:
+ *
+ *   [Board "3x3/3"]
+ *   [Date "2026.01.09"]
+ *   [Player1 "Alice"]
+ *   [Player2 "Bot"]
+ *   [Player2Model "MinimaxAI"]
+ *   [Result "b"]
+ *   [TimeControl "-"]
+ *
+ *   1. b2 a1 {only move} 2. c3 ... b
+ *
+ * A file may contain several records, separated by blank lines.
+ ******************************************************************************
+ */
+
+// Package record implements the game record format used to store complete
+// matches on disk.
+package record
+
+import (
+	"GoTicTacToe/game"
+	"fmt"
+	"time"
+)
+
+// Result tokens.
+//
+// A won game uses the notation letter of the winner ("a" for the first
+// player, "b" for the second, ...), as in game position notation.
+const (
+	// ResultOngoing marks a game that was recorded before it ended.
+	ResultOngoing = "*"
+
+	// ResultDraw marks a drawn game.
+	ResultDraw = "draw"
+)
+
+// NoTimeControl is the TimeControl value of an untimed game.
+const NoTimeControl = "-"
+
+// Termination tag, stored in Extra when a game did not end on the board.
+const (
+	tagTermination         = "Termination"
+	terminationTimeForfeit = "time forfeit"
+	terminationDeadDraw    = "dead position"
+)
+
+// Record layout constants.
+const (
+	// dateLayout is the layout of the Date tag (PGN style).
+	dateLayout = "2006.01.02"
+
+	// unknownDate is written when Date is the zero time.
+	unknownDate = "????.??.??"
+
+	// firstResultLetter is the result letter of the first player.
+	firstResultLetter = 'a'
+)
+
+// PlayerInfo describes one participant of a recorded game.
+type PlayerInfo struct {
+	Name  string // Display name
+	Model string // AI model name, or "" for a human player
+}
+
+// MoveEntry is a single move of the move list with its optional comment.
+type MoveEntry struct {
+	Move    game.Move // Played cell
+	Comment string    // Optional annotation (without braces)
+}
+
+// Record is a complete recorded game: header information and move list.
+//
+// Moves are played in turn order starting from the side to move of Position
+// (or the first player when Position is empty).
+type Record struct {
+	Players     []PlayerInfo // Participants in turn order
+	Width       int          // Number of columns
+	Height      int          // Number of rows
+	ToWin       int          // Required aligned symbols to win
+	Date        time.Time    // Date the game was played (zero if unknown)
+	Result      string       // ResultOngoing, ResultDraw or the winner's letter
+	TimeControl string       // Free-form time control description
+	Position    string       // Starting position notation ("" = empty board)
+	Comment     string       // Comment placed before the first move
+	Moves       []MoveEntry  // Moves in play order
+
+	// Extra holds additional tags that have no dedicated field. They are
+	// preserved when reading and writing.
+	Extra map[string]string
+}
+
+// FromGame builds a record from the current state of g.
+//
+// models maps players to the name of the AI controlling them; it may be nil.
+// The Date is left unset so the caller can choose the clock.
+func FromGame(g *game.Game, models map[*game.Player]string) *Record {
+	r := &Record{
+		Width:       g.Board.Width,
+		Height:      g.Board.Height,
+		ToWin:       g.Board.ToWin,
+		Result:      ResultOngoing,
+		TimeControl: NoTimeControl,
+		Position:    g.StartPosition,
+	}
+	if g.Clock != nil {
+		r.TimeControl = g.Clock.Control.String()
+	}
+
+	for _, p := range g.Players {
+		r.Players = append(r.Players, PlayerInfo{Name: p.Name, Model: models[p]})
+	}
+	for _, mv := range g.History {
+		r.Moves = append(r.Moves, MoveEntry{Move: mv})
+	}
+
+	if g.State == game.GAME_END {
+		r.Result = ResultDraw
+		if g.Winner != nil {
+			r.Result = WinnerResult(g.Winner.ID)
+		}
+
+		switch g.Reason {
+		case game.END_TIMEOUT:
+			r.Extra = map[string]string{tagTermination: terminationTimeForfeit}
+		case game.END_DEAD_DRAW:
+			r.Extra = map[string]string{tagTermination: terminationDeadDraw}
+		}
+	}
+
+	return r
+}
+
+// WinnerResult returns the result token of a game won by the player with the
+// given ID.
+func WinnerResult(id int) string {
+	return string(rune(firstResultLetter + id))
+}
+
+// Replay rebuilds the game described by the record and plays all its moves.
+//
+// players must contain one player per PlayerInfo, in the same order. An
+// error is returned if the starting position is invalid or a move is illegal.
+func (r *Record) Replay(players []*game.Player) (*game.Game, error) {
+	if len(players) != len(r.Players) {
+		return nil, fmt.Errorf("record has %d players, got %d", len(r.Players), len(players))
+	}
+
+	g := game.NewGameWithConfig(r.Width, r.Height, r.ToWin, players)
+	if r.Position != "" {
+		if err := g.Decode(r.Position); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, entry := range r.Moves {
+		if g.State != game.PLAYING {
+			return nil, fmt.Errorf("move %d (%s): game is already over", i+1, entry.Move)
+		}
+		if !g.PlayMove(entry.Move.X, entry.Move.Y) {
+			return nil, fmt.Errorf("move %d (%s): illegal move", i+1, entry.Move)
+		}
+	}
+
+	return g, nil
+}
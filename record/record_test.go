@@ -0,0 +1,193 @@
+package record
+
+import (
+	"GoTicTacToe/game"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// moves builds a move list from coordinate notation, without comments.
+func moves(t *testing.T, coords ...string) []MoveEntry {
+	t.Helper()
+	entries := make([]MoveEntry, len(coords))
+	for i, c := range coords {
+		m, err := game.ParseMove(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[i] = MoveEntry{Move: m}
+	}
+	return entries
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	twoPlayers := []PlayerInfo{{Name: "Alice"}, {Name: "Bot", Model: "MinimaxAI"}}
+
+	tests := []struct {
+		name string
+		rec  func(t *testing.T) *Record
+	}{
+		{
+			name: "no moves",
+			rec: func(t *testing.T) *Record {
+				return &Record{Players: twoPlayers, Width: 3, Height: 3, ToWin: 3,
+					Result: ResultOngoing, TimeControl: NoTimeControl}
+			},
+		},
+		{
+			name: "tags",
+			rec: func(t *testing.T) *Record {
+				return &Record{
+					Players: []PlayerInfo{{Name: "Alice"}, {Name: "Bob"}, {Name: "Bot", Model: "MCTSAI"}},
+					Width:   5, Height: 4, ToWin: 3,
+					Date:        time.Date(2026, time.January, 9, 0, 0, 0, 0, time.UTC),
+					Result:      "c",
+					TimeControl: "5m0s+3s",
+					Moves:       moves(t, "a1", "b2", "c3"),
+					Extra:       map[string]string{"Event": "Club \"night\"", tagTermination: terminationTimeForfeit},
+				}
+			},
+		},
+		{
+			name: "comments",
+			rec: func(t *testing.T) *Record {
+				r := &Record{Players: twoPlayers, Width: 3, Height: 3, ToWin: 3,
+					Result: ResultDraw, TimeControl: NoTimeControl, Comment: "classic opening",
+					Moves: moves(t, "b2", "a1", "c3", "a3", "a2", "c1", "b1", "b3", "c2")}
+				r.Moves[1].Comment = "only move"
+				r.Moves[4].Comment = "forced\nblock on the next move"
+				r.Moves[8].Comment = "board full"
+				return r
+			},
+		},
+		{
+			name: "position",
+			rec: func(t *testing.T) *Record {
+				return &Record{Players: twoPlayers, Width: 3, Height: 3, ToWin: 3,
+					Result: "a", TimeControl: NoTimeControl, Position: "3x3/3 a2/1b1/3 a 2 -",
+					Moves: moves(t, "a2", "c3", "a3")}
+			},
+		},
+		{
+			name: "wrapped movetext",
+			rec: func(t *testing.T) *Record {
+				r := &Record{Players: twoPlayers, Width: 15, Height: 15, ToWin: 5,
+					Result: ResultOngoing, TimeControl: NoTimeControl}
+				for i := 0; i < 60; i++ {
+					r.Moves = append(r.Moves, MoveEntry{Move: game.Move{X: i % 15, Y: i / 15 * 2}})
+				}
+				r.Moves[30].Comment = "a comment long enough to wrap the current line of movetext"
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.rec(t)
+			text := want.String()
+
+			got, err := Read(strings.NewReader(text))
+			if err != nil {
+				t.Fatalf("Read: %v\n%s", err, text)
+			}
+			if len(got) != 1 {
+				t.Fatalf("Read returned %d records, want 1\n%s", len(got), text)
+			}
+			if !reflect.DeepEqual(got[0], want) {
+				t.Errorf("round trip mismatch\ngot:  %+v\nwant: %+v\n%s", got[0], want, text)
+			}
+			for _, line := range strings.Split(text, "\n") {
+				if len(line) > maxLineLength && !strings.HasPrefix(line, "[") {
+					t.Errorf("line longer than %d characters: %q", maxLineLength, line)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteReadSeveralRecords(t *testing.T) {
+	records := []*Record{
+		{Players: []PlayerInfo{{Name: "A"}, {Name: "B"}}, Width: 3, Height: 3, ToWin: 3,
+			Result: "a", TimeControl: NoTimeControl, Moves: moves(t, "a1", "b1", "a2", "b2", "a3")},
+		{Players: []PlayerInfo{{Name: "B"}, {Name: "A"}}, Width: 3, Height: 3, ToWin: 3,
+			Result: ResultOngoing, TimeControl: NoTimeControl, Moves: moves(t, "b2")},
+	}
+
+	var sb strings.Builder
+	if err := Write(&sb, records...); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("Read: %v\n%s", err, sb.String())
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("round trip mismatch\ngot:  %+v\nwant: %+v", got, records)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		coords   []string
+	}{
+		{name: "empty board", coords: []string{"b2", "a1", "c3", "a3", "a2", "c1", "b1", "b3", "c2"}},
+		{name: "win", coords: []string{"a1", "b1", "a2", "b2", "a3"}},
+		{name: "position", position: "3x3/3 a2/1b1/3 a 2 -", coords: []string{"a2", "c3", "a3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game.NewGame()
+			if tt.position != "" {
+				if err := g.Decode(tt.position); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, c := range tt.coords {
+				m, err := game.ParseMove(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !g.PlayMove(m.X, m.Y) {
+					t.Fatalf("move %s refused", c)
+				}
+			}
+
+			recs, err := Read(strings.NewReader(FromGame(g, nil).String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			replayed, err := recs[0].Replay([]*game.Player{
+				game.NewPlayer("", game.CircleSymbol),
+				game.NewPlayer("", game.CrossSymbol),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := replayed.Encode(), g.Encode(); got != want {
+				t.Errorf("replayed position %q, want %q", got, want)
+			}
+			if replayed.State != g.State || replayed.Reason != g.Reason {
+				t.Errorf("replayed state %v/%v, want %v/%v", replayed.State, replayed.Reason, g.State, g.Reason)
+			}
+			if (replayed.Winner == nil) != (g.Winner == nil) ||
+				(g.Winner != nil && replayed.Winner.ID != g.Winner.ID) {
+				t.Errorf("replayed winner %v, want %v", replayed.Winner, g.Winner)
+			}
+		})
+	}
+}
+
+func TestReplayRejectsIllegalMoves(t *testing.T) {
+	r := &Record{Players: []PlayerInfo{{Name: "A"}, {Name: "B"}}, Width: 3, Height: 3, ToWin: 3,
+		Result: ResultOngoing, Moves: moves(t, "b2", "b2")}
+	if _, err := r.Replay([]*game.Player{game.NewPlayer("", game.CircleSymbol), game.NewPlayer("", game.CrossSymbol)}); err == nil {
+		t.Error("Replay accepted a move on an occupied cell")
+	}
+}
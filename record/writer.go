@@ -0,0 +1,190 @@
+/**
+ ******************************************************************************
+ * @file            : writer.go
+ * @brief           : GoTicTacToe - Game record writer
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the serialization of records to the text format.
+ ******************************************************************************
+ */
+
+package record
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tag names with a dedicated Record field.
+const (
+	tagBoard       = "Board"
+	tagDate        = "Date"
+	tagResult      = "Result"
+	tagTimeControl = "TimeControl"
+	tagPosition    = "Position"
+
+	// tagPlayerPrefix is followed by the 1-based player number ("Player1").
+	tagPlayerPrefix = "Player"
+
+	// tagModelSuffix is appended to a player tag for AI players ("Player1Model").
+	tagModelSuffix = "Model"
+)
+
+// Movetext layout constants.
+const (
+	// maxLineLength is the soft limit of a movetext line.
+	maxLineLength = 79
+
+	// commentOpen and commentClose delimit a move comment.
+	commentOpen  = "{"
+	commentClose = "}"
+)
+
+// Write serializes the records to w, separated by blank lines.
+func Write(w io.Writer, records ...*Record) error {
+	bw := bufio.NewWriter(w)
+	for i, r := range records {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		if err := r.write(bw); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// String returns the text form of the record.
+func (r *Record) String() string {
+	var sb strings.Builder
+	_ = Write(&sb, r)
+	return sb.String()
+}
+
+// write serializes a single record: header, blank line, movetext.
+func (r *Record) write(w *bufio.Writer) error {
+	for _, tag := range r.tags() {
+		if strings.ContainsAny(tag[0], " \t\n\"[]") {
+			return fmt.Errorf("invalid tag name %q", tag[0])
+		}
+		fmt.Fprintf(w, "[%s %s]\n", tag[0], strconv.Quote(tag[1]))
+	}
+	w.WriteString("\n")
+
+	line := &lineWriter{w: w}
+	if r.Comment != "" {
+		if err := line.comment(r.Comment); err != nil {
+			return err
+		}
+	}
+
+	perRound := len(r.Players)
+	if perRound == 0 {
+		perRound = 1
+	}
+	for i, entry := range r.Moves {
+		if i%perRound == 0 {
+			line.token(strconv.Itoa(i/perRound+1) + ".")
+		}
+		line.token(entry.Move.String())
+		if entry.Comment != "" {
+			if err := line.comment(entry.Comment); err != nil {
+				return err
+			}
+		}
+	}
+
+	result := r.Result
+	if result == "" {
+		result = ResultOngoing
+	}
+	line.token(result)
+	w.WriteString("\n")
+	return nil
+}
+
+// tags returns the ordered header of the record as (name, value) pairs.
+//
+// Known tags come first in a fixed order, followed by Extra sorted by name.
+func (r *Record) tags() [][2]string {
+	date := unknownDate
+	if !r.Date.IsZero() {
+		date = r.Date.Format(dateLayout)
+	}
+	timeControl := r.TimeControl
+	if timeControl == "" {
+		timeControl = NoTimeControl
+	}
+	result := r.Result
+	if result == "" {
+		result = ResultOngoing
+	}
+
+	tags := [][2]string{
+		{tagBoard, fmt.Sprintf("%dx%d/%d", r.Width, r.Height, r.ToWin)},
+		{tagDate, date},
+	}
+	for i, p := range r.Players {
+		name := tagPlayerPrefix + strconv.Itoa(i+1)
+		tags = append(tags, [2]string{name, p.Name})
+		if p.Model != "" {
+			tags = append(tags, [2]string{name + tagModelSuffix, p.Model})
+		}
+	}
+	tags = append(tags,
+		[2]string{tagResult, result},
+		[2]string{tagTimeControl, timeControl},
+	)
+	if r.Position != "" {
+		tags = append(tags, [2]string{tagPosition, r.Position})
+	}
+
+	extra := make([]string, 0, len(r.Extra))
+	for name := range r.Extra {
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		tags = append(tags, [2]string{name, r.Extra[name]})
+	}
+
+	return tags
+}
+
+// lineWriter writes movetext tokens separated by spaces and wraps long lines.
+type lineWriter struct {
+	w   *bufio.Writer
+	col int // Current column in the line
+}
+
+// token writes a single token, breaking the line if it would get too long.
+func (l *lineWriter) token(tok string) {
+	if l.col > 0 && l.col+1+len(tok) > maxLineLength {
+		l.w.WriteString("\n")
+		l.col = 0
+	}
+	if l.col > 0 {
+		l.w.WriteString(" ")
+		l.col++
+	}
+	l.w.WriteString(tok)
+	l.col += len(tok)
+}
+
+// comment writes a brace-delimited comment as a single token.
+func (l *lineWriter) comment(text string) error {
+	if strings.Contains(text, commentClose) {
+		return fmt.Errorf("comment %q must not contain %q", text, commentClose)
+	}
+	l.token(commentOpen + text + commentClose)
+	return nil
+}
@@ -0,0 +1,117 @@
+package screens
+
+import (
+	"GoTicTacToe/ai_models"
+	"GoTicTacToe/game"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// aiTurn is an AI move being computed on a background goroutine.
+//
+// The model works on a snapshot, so the game loop keeps running (and
+// drawing) while it thinks. The move is applied by the game loop with
+// Handle.TryMove, which rejects it if the game changed in the meantime.
+type aiTurn struct {
+	version uint64             // Snapshot version the move is computed for
+	player  *game.Player       // Live player the move is computed for
+	started time.Time          // When the search started
+	cancel  context.CancelFunc // Stops the search
+	result  chan aiResult
+
+	res  aiResult // Search result (valid once done)
+	done bool     // Whether the model has answered
+}
+
+// aiResult is the outcome of an AI search.
+type aiResult struct {
+	move game.Move
+	info ai_models.Info
+	err  error
+}
+
+// startAITurn starts computing the move of player with model on snap.
+func (gs *GameScreen) startAITurn(model ai_models.AIModel, player *game.Player, snap *game.Snapshot) *aiTurn {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &aiTurn{
+		version: snap.Version,
+		player:  player,
+		started: time.Now(),
+		cancel:  cancel,
+		// Buffered so that the goroutine never blocks, even when the turn
+		// was cancelled and nobody reads the result anymore.
+		result: make(chan aiResult, 1),
+	}
+
+	go func() {
+		m, info, err := gs.aiMove(ctx, model, snap)
+		t.result <- aiResult{move: m, info: info, err: err}
+	}()
+	return t
+}
+
+// updateAITurn drives the AI turn of player on snap: it starts the search
+// if needed and plays the move once it is available and the minimum delay
+// has elapsed.
+func (gs *GameScreen) updateAITurn(model ai_models.AIModel, player *game.Player, snap *game.Snapshot) {
+	if gs.aiTurn == nil || gs.aiTurn.version != snap.Version {
+		gs.cancelAITurn()
+		gs.aiTurn = gs.startAITurn(model, player, snap)
+	}
+
+	t := gs.aiTurn
+	if !t.done {
+		select {
+		case t.res = <-t.result:
+			t.done = true
+		default:
+			return
+		}
+	}
+	if time.Since(t.started) < gs.aiMinDelay {
+		return
+	}
+
+	gs.aiTurn = nil
+	t.cancel()
+
+	m := t.res.move
+	if t.res.err != nil {
+		m = gs.aiFallbackMove(player, snap, t.res.err)
+	} else {
+		gs.aiNotice = ""
+	}
+	if _, ok := gs.handle.TryMove(t.version, m.X, m.Y); !ok && t.res.err == nil {
+		log.Printf("%s (AI) move %s was rejected", player.Name, m)
+	}
+}
+
+// aiFallbackMove reports a failed AI search and returns a random legal move
+// instead, so that the game goes on.
+func (gs *GameScreen) aiFallbackMove(player *game.Player, snap *game.Snapshot, err error) game.Move {
+	log.Printf("%s (AI) failed to move: %v", player.Name, err)
+	gs.aiNotice = fmt.Sprintf("%s (AI) failed to move, a random move was played", player.Name)
+
+	x, y := ai_models.RandomAI{}.NextMove(snap.Board, snap.Current, snap.Players)
+	return game.Move{X: x, Y: y}
+}
+
+// cancelAITurn stops the AI search in progress, if any, and discards its
+// move.
+func (gs *GameScreen) cancelAITurn() {
+	if gs.aiTurn != nil {
+		gs.aiTurn.cancel()
+		gs.aiTurn = nil
+	}
+}
+
+// thinkingPlayer returns the player whose AI move is pending, including the
+// minimum delay (nil if none).
+func (gs *GameScreen) thinkingPlayer() *game.Player {
+	if gs.aiTurn == nil {
+		return nil
+	}
+	return gs.aiTurn.player
+}
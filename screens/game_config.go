@@ -22,8 +22,9 @@ package screens
 
 import (
 	"GoTicTacToe/ai_models"
-	"GoTicTacToe/assets"
+	"GoTicTacToe/game"
 	"image/color"
+	"time"
 )
 
 // Default game configuration values.
@@ -33,6 +34,10 @@ const (
 	defaultToWin       = 3
 
 	defaultColorAlpha = 255
+
+	// aiVsAIMinDelay slows down instant AI moves in games between AI players
+	// only, so they can be followed.
+	aiVsAIMinDelay = 400 * time.Millisecond
 )
 
 // Default player colors.
@@ -48,45 +53,89 @@ var (
 type PlayerConfig struct {
 	Name    string            // Display name of the player
 	Color   color.Color       // Player color used in the UI
-	Symbol  assets.SymbolType // Symbol associated with the player
+	Symbol  game.SymbolType   // Symbol associated with the player
 	IsAI    bool              // Indicates whether the player is AI-controlled
-	AIModel ai_models.AIModel // AI strategy (used only if IsAI is true)
+	AIModel ai_models.AIModel // AI strategy (used only if IsAI is true, nil = SkillAI at Level)
+	Level   int               // AI skill level (0 = ai_models.DefaultSkillLevel)
 	Ready   bool              // Indicates whether the player is ready to start
 }
 
+// Model returns the AI model playing for the player: AIModel if set, a
+// SkillAI at Level otherwise (searching on every CPU at the top level), or
+// nil for a human player.
+func (pc PlayerConfig) Model() ai_models.AIModel {
+	if !pc.IsAI {
+		return nil
+	}
+	if pc.AIModel != nil {
+		return pc.AIModel
+	}
+	return ai_models.SkillAI{Level: pc.Level, Workers: ai_models.DefaultWorkers()}
+}
+
 // GameConfig aggregates the full setup required before launching a match.
 //
 // It defines the board dimensions, the win condition, and all participating
-// players.
+// players. If Position is set, the match starts from that position (see
+// game.Game.Decode) instead of an empty board; its dimensions then override
+// BoardWidth, BoardHeight and ToWin.
 type GameConfig struct {
 	BoardWidth  int            // Number of columns in the grid
 	BoardHeight int            // Number of rows in the grid
 	ToWin       int            // Number of aligned symbols required to win
 	Players     []PlayerConfig // Player configurations
+	Position    string         // Optional starting position in game notation
+
+	// DeadDrawDetection ends a round early once nobody can complete a line.
+	DeadDrawDetection bool
+
+	// TimeControl enables chess clocks (nil for an untimed match).
+	TimeControl *game.TimeControl
+
+	// Match defines the series format, first-player rotation and tiebreak.
+	Match game.MatchConfig
+
+	// AIMinDelay is the minimum time an AI move takes, so that AI-vs-AI
+	// games are watchable (0 plays AI moves as soon as they are found). The
+	// setup screen enables it when every player is an AI.
+	AIMinDelay time.Duration
 }
 
 // DefaultGameConfig returns a ready-to-play configuration.
 //
 // The default configuration represents a classic 3x3 Tic-Tac-Toe game
-// with two human players.
+// with two human players. Rounds are played out until the board is full (dead
+// draws are not detected), the first move alternates between rounds of an
+// endless series and AI moves are played as soon as they are found.
 func DefaultGameConfig() GameConfig {
 	return GameConfig{
 		BoardWidth:  defaultBoardWidth,
 		BoardHeight: defaultBoardHeight,
 		ToWin:       defaultToWin,
+		Match:       game.MatchConfig{Rotation: game.ROTATE_ALTERNATE},
 		Players: []PlayerConfig{
 			{
 				Name:   "Player 1",
 				Color:  defaultPlayer1Color,
-				Symbol: assets.CircleSymbol,
+				Symbol: game.CircleSymbol,
 				Ready:  false,
 			},
 			{
 				Name:   "Player 2",
 				Color:  defaultPlayer2Color,
-				Symbol: assets.CrossSymbol,
+				Symbol: game.CrossSymbol,
 				Ready:  false,
 			},
 		},
 	}
 }
+
+// onlyAI reports whether every player is controlled by an AI.
+func (cfg GameConfig) onlyAI() bool {
+	for _, pc := range cfg.Players {
+		if !pc.IsAI {
+			return false
+		}
+	}
+	return len(cfg.Players) > 0
+}
@@ -3,12 +3,21 @@ package screens
 import (
 	"GoTicTacToe/ai_models"
 	"GoTicTacToe/assets"
+	"GoTicTacToe/book"
 	"GoTicTacToe/game"
+	"GoTicTacToe/record"
 	"GoTicTacToe/ui"
 	uiutils "GoTicTacToe/ui/utils"
+	"context"
+	"errors"
 	"fmt"
 	"image/color"
+	"io/fs"
+	"log"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
@@ -19,10 +28,18 @@ import (
 // It handles the game logic, UI board rendering, and HUD display.
 type GameScreen struct {
 	host      ScreenHost
-	game      *game.Game
+	game      *game.Game   // Live game, only mutated through handle
+	handle    *game.Handle // Serializes mutations and publishes snapshots
+	match     *game.Match
 	boardView *ui.BoardView
 	scoreView *ui.ScoreView
+	clockView *ui.ClockView
 	playerAI  map[*game.Player]ai_models.AIModel
+	rounds    []*record.Record // Records of the finished rounds of the match
+
+	aiTurn     *aiTurn       // AI move being computed (nil if none)
+	aiMinDelay time.Duration // Minimum time an AI move takes
+	aiNotice   string        // Last AI failure shown to the user ("" if none)
 }
 
 const (
@@ -36,14 +53,31 @@ const (
 	scorePixelWidth  = 300
 	scorePixelHeight = 80
 
-	// Sentinel for "no move".
-	noMoveCoord = -1
+	// Clock view height in pixels (same width as the score view).
+	clockPixelHeight = 36
 
 	// Number of frames a key must be held to trigger global action.
 	keyHoldFramesToTrigger = 60
 
 	// Opaque alpha channel value.
 	colorAlphaOpaque = 255
+
+	// Vertical gap between the end message and its reason line.
+	endReasonOffsetY = 70.0
+
+	// Distance of the AI failure notice from the bottom of the screen.
+	aiNoticeMarginY = 30.0
+
+	// File the current match is appended to when exported (key E).
+	recordExportPath = "game_records.txt"
+
+	// openingBookPattern locates the bundled opening book of a board
+	// configuration from its width, height and win length.
+	openingBookPattern = "assets/static/books/%dx%d-%d.book"
+
+	// bookMinLevel is the skill level from which AI players use the opening
+	// book: weaker levels must keep their mistakes.
+	bookMinLevel = 7
 )
 
 var (
@@ -80,27 +114,46 @@ func NewGameScreen(h ScreenHost, cfg GameConfig) *GameScreen {
 		toWin = minDim
 	}
 
-	players, aiMap := buildPlayers(cfg)
+	players, colors, aiMap := buildPlayers(cfg)
 
 	// Create game logic
 	g := game.NewGameWithConfig(boardWidth, boardHeight, toWin, players)
+	visuals := ui.NewPlayerVisuals(g.Players, colors)
+	g.DeadDrawDetection = cfg.DeadDrawDetection
+
+	// Start from a custom position if one was provided. The match keeps it
+	// for every round, so it must be decoded first.
+	if cfg.Position != "" {
+		if err := g.Decode(cfg.Position); err != nil {
+			log.Printf("ignoring starting position: %v", err)
+		}
+	}
+	match := game.NewMatch(g, cfg.Match)
+	g.SetTimeControl(cfg.TimeControl, game.SystemTime{})
+	withOpeningBook(aiMap, g.Board)
 
 	gs := &GameScreen{
-		host:     h,
-		game:     g,
-		playerAI: aiMap,
+		host:       h,
+		game:       g,
+		handle:     game.NewHandle(g),
+		match:      match,
+		playerAI:   aiMap,
+		aiMinDelay: cfg.AIMinDelay,
 	}
 
-	gs.scoreView = ui.NewScoreView(g, scorePixelWidth, scorePixelHeight, uiutils.DefaultWidgetStyle)
+	gs.scoreView = ui.NewScoreView(gs.handle, visuals, scorePixelWidth, scorePixelHeight, uiutils.DefaultWidgetStyle)
+	gs.scoreView.Match = match
+	gs.clockView = ui.NewClockView(gs.handle, visuals, scorePixelWidth, clockPixelHeight, scorePixelHeight, uiutils.DefaultWidgetStyle)
 
 	// Create the interactive board view with callback on cell click
 	gs.boardView = ui.NewBoardView(
-		g.Board, // Logical board reference
+		gs.handle.Snapshot().Board, // Replaced by the latest snapshot on each Draw
+		visuals,
 		0, 0,
 		boardPixelSize, // Pixel size
 		uiutils.DefaultWidgetStyle,
 		func(x, y int) {
-			gs.game.PlayMove(x, y)
+			gs.handle.PlayMove(x, y)
 		},
 	)
 
@@ -109,61 +162,198 @@ func NewGameScreen(h ScreenHost, cfg GameConfig) *GameScreen {
 
 // Update processes input and updates UI components.
 func (gs *GameScreen) Update() error {
-	// Handle AI board interactions
-	if gs.game.State == game.PLAYING {
-		current := gs.game.Current
-		if current.IsAI {
-			model := gs.playerAI[current]
-			if model != nil {
-				x, y := model.NextMove(gs.game.Board, current, gs.game.Players)
-				if x >= 0 && y >= 0 && x != noMoveCoord && y != noMoveCoord {
-					gs.game.PlayMove(x, y)
-				}
-			}
-			return nil // skip human input this frame
+	// End the round if the player to move ran out of time.
+	snap := gs.handle.Do((*game.Game).CheckTimeout)
+
+	// Handle AI board interactions: the move is computed in the background
+	// and played once available.
+	aiToMove := false
+	if snap.State == game.PLAYING {
+		current := gs.game.Players[snap.Current.ID]
+		if model := gs.playerAI[current]; current.IsAI && model != nil {
+			aiToMove = true
+			gs.updateAITurn(model, current, snap)
 		}
 	}
+	if !aiToMove {
+		gs.cancelAITurn()
+	}
+	gs.scoreView.Thinking = gs.thinkingPlayer()
+
+	gs.updateInput(aiToMove)
+	return nil
+}
+
+// updateInput processes the board clicks (unless an AI is to move) and the
+// round and global hotkeys.
+func (gs *GameScreen) updateInput(aiToMove bool) {
+	if aiToMove {
+		// Skip human input while the AI thinks, but keep the hotkeys.
+		gs.updateHotkeys()
+		return
+	}
 
 	// Handle Human board interactions
 	gs.boardView.Update()
 
-	// Reset the game if it's finished and the user clicks anywhere
-	if gs.game.State == game.GAME_END {
+	// Once a round is finished, a click starts the next round, or a new
+	// match if the series is decided.
+	if gs.handle.Snapshot().State == game.GAME_END {
+		gs.handle.Do(func(g *game.Game) bool {
+			if !gs.match.RecordRound() {
+				return false
+			}
+			// Keep the round: the next one clears the game history.
+			gs.rounds = append(gs.rounds, gs.roundRecord(g))
+			return true
+		})
 		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			gs.game.Reset()
+			gs.handle.Do(func(*game.Game) bool {
+				if !gs.match.NextRound() {
+					gs.match.Restart()
+					gs.rounds = nil
+				}
+				return true
+			})
 		}
 	}
 
-	// Global hotkeys
+	gs.updateHotkeys()
+}
+
+// updateHotkeys processes the global hotkeys.
+func (gs *GameScreen) updateHotkeys() {
 	if inpututil.KeyPressDuration(ebiten.KeyEscape) == keyHoldFramesToTrigger {
 		os.Exit(0)
 	}
 	if inpututil.KeyPressDuration(ebiten.KeyR) == keyHoldFramesToTrigger {
-		gs.game.Reset()
-		gs.game.ResetPoints()
+		gs.cancelAITurn()
+		gs.aiNotice = ""
+		gs.handle.Do(func(*game.Game) bool {
+			gs.match.Restart()
+			gs.rounds = nil
+			return true
+		})
 	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		gs.exportRecord()
+	}
+}
 
-	return nil
+// Close abandons the AI move in progress when the screen is left.
+func (gs *GameScreen) Close() {
+	gs.cancelAITurn()
+}
+
+// exportRecord appends the current match to the record file, one game per
+// round: every finished round, then the round in progress unless it has not
+// started after a finished one.
+//
+// Failures are only logged: exporting must never interrupt the game.
+func (gs *GameScreen) exportRecord() {
+	recs := slices.Clone(gs.rounds)
+	gs.handle.Read(func(g *game.Game) {
+		if g.State != game.GAME_END && (len(g.History) > 0 || len(recs) == 0) {
+			recs = append(recs, gs.roundRecord(g))
+		}
+	})
+
+	if err := record.AppendFile(recordExportPath, recs...); err != nil {
+		log.Printf("exporting game record: %v", err)
+		return
+	}
+	log.Printf("%d game records appended to %s", len(recs), recordExportPath)
+}
+
+// roundRecord returns the record of the round of g, dated now.
+func (gs *GameScreen) roundRecord(g *game.Game) *record.Record {
+	models := map[*game.Player]string{}
+	for p, model := range gs.playerAI {
+		models[p] = aiModelName(model)
+	}
+
+	rec := record.FromGame(g, models)
+	rec.Date = time.Now()
+	return rec
+}
+
+// aiModelName returns a short name for an AI model: its String method if it
+// has one, its type name otherwise.
+func aiModelName(model ai_models.AIModel) string {
+	if s, ok := model.(fmt.Stringer); ok {
+		return s.String()
+	}
+	name := fmt.Sprintf("%T", model)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// aiMove asks the model for the move of the player to move in snap, giving it
+// a time budget derived from the clock when the match is timed.
+//
+// The model works on the snapshot's private copy of the game, so the live
+// game can keep changing while it thinks. It is called on the AI turn's
+// goroutine (see aiTurn) and stops when ctx is cancelled.
+func (gs *GameScreen) aiMove(ctx context.Context, model ai_models.AIModel, snap *game.Snapshot) (game.Move, ai_models.Info, error) {
+	me := snap.Current
+
+	// Own moves left if the board were filled in turn order.
+	playerCount := len(snap.Players)
+	movesLeft := (snap.Board.EmptyCount() + playerCount - 1) / playerCount
+
+	var limits ai_models.Limits
+	if snap.Clock != nil {
+		limits.Budget = snap.Clock.MoveBudget(me.ID, movesLeft)
+	}
+	return ai_models.Adapt(model).Search(ctx, snap.Board, me, snap.Players, limits)
 }
 
 // Draw renders the board and HUD.
 func (gs *GameScreen) Draw(screen *ebiten.Image) {
+	// Draw a consistent view of the game, whatever happens concurrently.
+	snap := gs.handle.Snapshot()
+
 	// Draw board component
+	gs.boardView.SetBoard(snap.Board)
 	gs.boardView.Draw(screen)
 	gs.scoreView.Draw(screen)
+	gs.clockView.Draw(screen)
 
 	// Display win/draw message if needed
-	if gs.game.State == game.GAME_END {
-		gs.drawEndMessage(screen)
+	if snap.State == game.GAME_END {
+		gs.drawEndMessage(screen, snap)
+	}
+
+	if gs.aiNotice != "" {
+		gs.drawAINotice(screen)
 	}
 }
 
+// drawAINotice displays the last AI failure at the bottom of the screen.
+func (gs *GameScreen) drawAINotice(screen *ebiten.Image) {
+	opts := &text.DrawOptions{}
+	opts.PrimaryAlign = text.AlignCenter
+	opts.SecondaryAlign = text.AlignCenter
+
+	sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+	opts.GeoM.Translate(float64(sw)/2, float64(sh)-aiNoticeMarginY)
+	opts.ColorScale.ScaleWithColor(endMessageColor)
+	text.Draw(screen, gs.aiNotice, assets.NormalFont, opts)
+}
+
 // drawEndMessage displays a centered win/draw message at the end of a game.
-func (gs *GameScreen) drawEndMessage(screen *ebiten.Image) {
+func (gs *GameScreen) drawEndMessage(screen *ebiten.Image, snap *game.Snapshot) {
 	var msg string
-	if gs.game.Winner != nil {
-		msg = fmt.Sprintf("%s wins!", gs.game.Winner.Name)
-	} else {
+	switch {
+	case gs.match.State == game.MATCH_END && gs.match.Winner != nil:
+		msg = fmt.Sprintf("%s wins the match!", gs.match.Winner.Name)
+	case gs.match.State == game.MATCH_END:
+		msg = "The match is drawn!"
+	case snap.Winner != nil:
+		msg = fmt.Sprintf("%s wins!", snap.Winner.Name)
+	default:
 		msg = "It's a draw!"
 	}
 
@@ -176,12 +366,52 @@ func (gs *GameScreen) drawEndMessage(screen *ebiten.Image) {
 
 	opts.ColorScale.ScaleWithColor(endMessageColor)
 	text.Draw(screen, msg, assets.BigFont, opts)
+
+	// Explain rounds that did not end on the board.
+	reason := ""
+	switch snap.Reason {
+	case game.END_DEAD_DRAW:
+		reason = "Dead draw: nobody can complete a line anymore"
+	case game.END_TIMEOUT:
+		reason = fmt.Sprintf("%s ran out of time", snap.Current.Name)
+	}
+	if reason != "" {
+		reasonOpts := &text.DrawOptions{}
+		reasonOpts.PrimaryAlign = text.AlignCenter
+		reasonOpts.SecondaryAlign = text.AlignCenter
+		reasonOpts.GeoM.Translate(float64(sw)/2, float64(sh)/2+endReasonOffsetY)
+		reasonOpts.ColorScale.ScaleWithColor(endMessageColor)
+		text.Draw(screen, reason, assets.NormalFont, reasonOpts)
+	}
 }
 
-// buildPlayers turns the setup configuration into runtime players
-// and returns a map of AI models keyed by player for quick lookup.
-func buildPlayers(cfg GameConfig) ([]*game.Player, map[*game.Player]ai_models.AIModel) {
+// withOpeningBook makes the strong AI players of models play from the
+// bundled opening book of board's configuration, if there is one.
+func withOpeningBook(models map[*game.Player]ai_models.AIModel, board *game.Board) {
+	if len(models) == 0 {
+		return
+	}
+	b, err := book.ReadFile(fmt.Sprintf(openingBookPattern, board.Width, board.Height, board.WinLength()))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("opening book: %v", err)
+		}
+		return
+	}
+
+	for p, model := range models {
+		if skill, ok := model.(ai_models.SkillAI); ok && skill.EffectiveLevel() >= bookMinLevel {
+			models[p] = book.AI{Book: b, Model: model}
+		}
+	}
+}
+
+// buildPlayers turns the setup configuration into runtime players.
+// It also returns the display color of each player (same order) and a map
+// of AI models keyed by player for quick lookup.
+func buildPlayers(cfg GameConfig) ([]*game.Player, []color.Color, map[*game.Player]ai_models.AIModel) {
 	var players []*game.Player
+	var colors []color.Color
 	aiByPlayer := map[*game.Player]ai_models.AIModel{}
 
 	readyCount := 0
@@ -203,35 +433,28 @@ func buildPlayers(cfg GameConfig) ([]*game.Player, map[*game.Player]ai_models.AI
 		}
 		colorIdx++
 
-		sym := assets.NewSymbol(pc.Symbol)
-		p := game.NewPlayer(sym, c)
-		if pc.Name != "" {
-			p.Name = pc.Name
-		} else {
-			p.Name = fmt.Sprintf("Player %d", idx+1)
+		name := pc.Name
+		if name == "" {
+			name = fmt.Sprintf("Player %d", idx+1)
 		}
+		p := game.NewPlayer(name, pc.Symbol)
 		p.IsAI = pc.IsAI
 
 		players = append(players, p)
+		colors = append(colors, c)
 
-		if pc.IsAI {
-			model := pc.AIModel
-			if model == nil {
-				model = ai_models.RandomAI{}
-			}
+		if model := pc.Model(); model != nil {
 			aiByPlayer[p] = model
 		}
 	}
 
 	if len(players) == 0 {
 		players = []*game.Player{
-			game.NewPlayer(assets.NewSymbol(assets.CircleSymbol), defaultPlayerColors[0]),
-			game.NewPlayer(assets.NewSymbol(assets.CrossSymbol), defaultPlayerColors[1]),
-		}
-		for i, p := range players {
-			p.Name = fmt.Sprintf("Player %d", i+1)
+			game.NewPlayer("Player 1", game.CircleSymbol),
+			game.NewPlayer("Player 2", game.CrossSymbol),
 		}
+		colors = defaultPlayerColors[:len(players)]
 	}
 
-	return players, aiByPlayer
+	return players, colors, aiByPlayer
 }
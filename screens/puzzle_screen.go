@@ -0,0 +1,322 @@
+package screens
+
+import (
+	"GoTicTacToe/assets"
+	"GoTicTacToe/puzzle"
+	"GoTicTacToe/ui"
+	uiutils "GoTicTacToe/ui/utils"
+	"context"
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Puzzle files.
+const (
+	// puzzleFilePath is the bundled puzzle collection.
+	puzzleFilePath = "assets/static/puzzles.txt"
+
+	// puzzleProgressPath stores the user's results between sessions.
+	puzzleProgressPath = "puzzle_progress.txt"
+)
+
+// Puzzle screen layout constants (in pixels, relative to the screen center).
+const (
+	puzzleBoardPixelSize = 420.0
+	puzzleBoardOffsetY   = -10.0
+
+	puzzleTitleY    = 50.0
+	puzzleSubtitleY = 95.0
+	puzzleStatusY   = 240.0
+	puzzleHintY     = 275.0
+
+	puzzleButtonY       = 330.0
+	puzzleButtonWidth   = 140.0
+	puzzleButtonHeight  = 50.0
+	puzzleButtonSpacing = 160.0
+)
+
+// Puzzle screen colors.
+var (
+	puzzleBgTopColor    = color.RGBA{R: 0x11, G: 0x1f, B: 0x39, A: 0xFF}
+	puzzleBgBottomColor = color.RGBA{R: 0x0a, G: 0x12, B: 0x24, A: 0xFF}
+	puzzleSolvedColor   = color.RGBA{R: 120, G: 220, B: 120, A: colorAlphaOpaque}
+	puzzleFailedColor   = color.RGBA{R: 255, G: 110, B: 110, A: colorAlphaOpaque}
+	puzzleHintColor     = color.RGBA{R: 200, G: 200, B: 200, A: colorAlphaOpaque}
+)
+
+// PuzzleScreen lets the user solve "win in N" puzzles one after the other.
+type PuzzleScreen struct {
+	host       ScreenHost
+	root       *ui.Container
+	boardView  *ui.BoardView
+	background *ebiten.Image
+
+	puzzles  []*puzzle.Puzzle
+	progress puzzle.Progress
+	index    int             // Index of the current puzzle
+	attempt  *puzzle.Attempt // Current attempt (nil if it could not start)
+	turn     *puzzleTurn     // Solver move being answered (nil if none)
+	showHint bool            // Whether the hint of the current puzzle is shown
+	loadErr  error           // Error while loading the puzzles, if any
+}
+
+// puzzleTurn is a solver move being checked and answered on a background
+// goroutine, so that the screen keeps running (and drawing) meanwhile. The
+// attempt is left unchanged until the result is applied by Update.
+type puzzleTurn struct {
+	cancel context.CancelFunc // Stops the evaluation
+	result chan puzzleResult
+}
+
+// puzzleResult is the outcome of puzzle.Attempt.Evaluate.
+type puzzleResult struct {
+	reply puzzle.Reply
+	err   error
+}
+
+// NewPuzzleScreen loads the puzzle collection and the saved progress, and
+// opens the first unsolved puzzle.
+func NewPuzzleScreen(h ScreenHost) *PuzzleScreen {
+	s := &PuzzleScreen{host: h}
+
+	s.puzzles, s.loadErr = puzzle.ReadFile(puzzleFilePath)
+
+	progress, err := puzzle.LoadProgress(puzzleProgressPath)
+	if err != nil {
+		log.Printf("ignoring puzzle progress: %v", err)
+		progress = puzzle.Progress{}
+	}
+	s.progress = progress
+
+	s.buildButtons()
+
+	for i, p := range s.puzzles {
+		if !s.progress.Of(p).Solved {
+			s.index = i
+			break
+		}
+	}
+	s.startAttempt()
+	return s
+}
+
+// buildButtons creates the navigation buttons below the board.
+func (s *PuzzleScreen) buildButtons() {
+	s.root = ui.NewContainer(0, 0, 100, 100, uiutils.AnchorTopLeft, 0, uiutils.TransparentWidgetStyle)
+	s.root.WidthMode = uiutils.SizeFill
+	s.root.HeightMode = uiutils.SizeFill
+
+	buttons := []struct {
+		label   string
+		style   uiutils.WidgetStyle
+		onClick func()
+	}{
+		{"< Prev", uiutils.DefaultWidgetStyle, func() { s.move(-1) }},
+		{"Hint", uiutils.DefaultWidgetStyle, func() { s.showHint = !s.showHint }},
+		{"Retry", uiutils.NormalWidgetStyle, s.startAttempt},
+		{"Next >", uiutils.DefaultWidgetStyle, func() { s.move(+1) }},
+		{"Back", uiutils.TransparentWidgetStyle, func() { s.host.SetScreen(NewStartScreen(s.host)) }},
+	}
+
+	first := -puzzleButtonSpacing * float64(len(buttons)-1) * half
+	for i, b := range buttons {
+		s.root.AddChild(ui.NewButton(b.label, first+float64(i)*puzzleButtonSpacing, puzzleButtonY,
+			uiutils.AnchorCenter, puzzleButtonWidth, puzzleButtonHeight, buttonRadius, b.style, b.onClick))
+	}
+}
+
+// move opens the puzzle delta positions away, wrapping around.
+func (s *PuzzleScreen) move(delta int) {
+	if len(s.puzzles) == 0 {
+		return
+	}
+	s.index = (s.index + delta + len(s.puzzles)) % len(s.puzzles)
+	s.startAttempt()
+}
+
+// startAttempt (re)starts the current puzzle from its position.
+func (s *PuzzleScreen) startAttempt() {
+	s.cancelTurn()
+	s.attempt = nil
+	s.boardView = nil
+	s.showHint = false
+	if len(s.puzzles) == 0 {
+		return
+	}
+
+	a, err := puzzle.NewAttempt(s.puzzles[s.index])
+	if err != nil {
+		log.Printf("puzzle %d: %v", s.index+1, err)
+		return
+	}
+	s.attempt = a
+
+	visuals := ui.NewPlayerVisuals(a.Game.Players, defaultPlayerColors)
+	s.boardView = ui.NewBoardView(a.Game.Board, visuals, 0, puzzleBoardOffsetY, puzzleBoardPixelSize,
+		uiutils.DefaultWidgetStyle, s.play)
+}
+
+// play submits the user's move: it is checked and answered on a background
+// goroutine (see updateTurn).
+func (s *PuzzleScreen) play(x, y int) {
+	a := s.attempt
+	if a == nil || s.turn != nil || !a.CanPlay(x, y) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &puzzleTurn{
+		cancel: cancel,
+		// Buffered so that the goroutine never blocks once the turn is
+		// cancelled.
+		result: make(chan puzzleResult, 1),
+	}
+	go func() {
+		r, err := a.Evaluate(ctx, x, y)
+		t.result <- puzzleResult{reply: r, err: err}
+	}()
+	s.turn = t
+}
+
+// updateTurn plays the answered solver move, if any, and records finished
+// attempts.
+func (s *PuzzleScreen) updateTurn() {
+	t := s.turn
+	if t == nil {
+		return
+	}
+	var res puzzleResult
+	select {
+	case res = <-t.result:
+	default:
+		return
+	}
+	s.turn = nil
+	t.cancel()
+
+	a := s.attempt
+	if res.err != nil {
+		log.Printf("puzzle %d: %v", s.index+1, res.err)
+		return
+	}
+	if !a.Apply(res.reply) || a.State == puzzle.ATTEMPT_PLAYING {
+		return
+	}
+
+	s.progress.Record(a.Puzzle, a.State == puzzle.ATTEMPT_SOLVED)
+	if err := s.progress.Save(puzzleProgressPath); err != nil {
+		log.Printf("saving puzzle progress: %v", err)
+	}
+}
+
+// cancelTurn stops the evaluation of the solver move in progress, if any,
+// and discards it.
+func (s *PuzzleScreen) cancelTurn() {
+	if s.turn != nil {
+		s.turn.cancel()
+		s.turn = nil
+	}
+}
+
+// Close abandons the move being answered when the screen is left.
+func (s *PuzzleScreen) Close() {
+	s.cancelTurn()
+}
+
+// Update applies answered moves and processes input for the board and
+// buttons.
+func (s *PuzzleScreen) Update() error {
+	s.updateTurn()
+	if s.boardView != nil {
+		s.boardView.Update()
+	}
+	s.root.Update()
+	return nil
+}
+
+// Draw renders the current puzzle, its status and the buttons.
+func (s *PuzzleScreen) Draw(screen *ebiten.Image) {
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+
+	if s.background == nil {
+		s.background = uiutils.CreateGradientBackground(w, h, puzzleBgTopColor, puzzleBgBottomColor)
+	}
+	screen.DrawImage(s.background, nil)
+
+	switch {
+	case s.loadErr != nil:
+		s.drawText(screen, "Puzzles unavailable", assets.BigFont, puzzleTitleY, color.White)
+		s.drawCentered(screen, s.loadErr.Error(), puzzleFailedColor)
+	case len(s.puzzles) == 0:
+		s.drawText(screen, "No puzzles", assets.BigFont, puzzleTitleY, color.White)
+	default:
+		s.drawPuzzle(screen)
+	}
+
+	s.root.Draw(screen)
+}
+
+// drawPuzzle renders the title, board and status of the current puzzle.
+func (s *PuzzleScreen) drawPuzzle(screen *ebiten.Image) {
+	p := s.puzzles[s.index]
+	s.drawText(screen, p.Name(), assets.BigFont, puzzleTitleY, color.White)
+
+	subtitle := fmt.Sprintf("Puzzle %d/%d - Win in %d - Solved %d/%d",
+		s.index+1, len(s.puzzles), p.WinIn, s.progress.SolvedCount(s.puzzles), len(s.puzzles))
+	if p.Rating > 0 {
+		subtitle += fmt.Sprintf(" - Difficulty %d/5", p.Rating)
+	}
+	s.drawText(screen, subtitle, assets.NormalFont, puzzleSubtitleY, puzzleHintColor)
+
+	a := s.attempt
+	if a == nil || s.boardView == nil {
+		s.drawCentered(screen, "This puzzle is invalid", puzzleFailedColor)
+		return
+	}
+	s.boardView.Draw(screen)
+
+	status, statusColor := "", color.Color(color.White)
+	switch a.State {
+	case puzzle.ATTEMPT_PLAYING:
+		status = fmt.Sprintf("Your move - %d move(s) left", a.MovesLeft)
+		if s.turn != nil {
+			status = "Checking your move..."
+		}
+	case puzzle.ATTEMPT_SOLVED:
+		status, statusColor = "Solved!", puzzleSolvedColor
+	case puzzle.ATTEMPT_FAILED:
+		status, statusColor = "Failed: that move lets the win slip away", puzzleFailedColor
+		if a.HasRefutation {
+			status = fmt.Sprintf("Failed: refuted by %s", a.Refutation)
+		}
+	}
+	sh := float64(screen.Bounds().Dy())
+	s.drawText(screen, status, assets.NormalFont, sh*half+puzzleStatusY, statusColor)
+
+	if s.showHint {
+		hint := p.Hint
+		if hint == "" {
+			hint = "No hint for this puzzle"
+		}
+		s.drawText(screen, hint, assets.NormalFont, sh*half+puzzleHintY, puzzleHintColor)
+	}
+}
+
+// drawCentered draws a message in the middle of the screen.
+func (s *PuzzleScreen) drawCentered(screen *ebiten.Image, msg string, c color.Color) {
+	s.drawText(screen, msg, assets.NormalFont, float64(screen.Bounds().Dy())*half, c)
+}
+
+// drawText draws a horizontally centered line of text at height y.
+func (s *PuzzleScreen) drawText(screen *ebiten.Image, msg string, face text.Face, y float64, c color.Color) {
+	opts := &text.DrawOptions{}
+	opts.PrimaryAlign = text.AlignCenter
+	opts.SecondaryAlign = text.AlignCenter
+	opts.ColorScale.ScaleWithColor(c)
+	opts.GeoM.Translate(float64(screen.Bounds().Dx())*half, y)
+	text.Draw(screen, msg, face, opts)
+}
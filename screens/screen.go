@@ -1,6 +1,7 @@
 package screens
 
 import (
+	"GoTicTacToe/ai_models"
 	"GoTicTacToe/ui"
 	"fmt"
 	"image"
@@ -18,6 +19,12 @@ type Screen interface {
 	Draw(screen *ebiten.Image)
 }
 
+// screenCloser is implemented by screens that own background work (such as
+// an AI search) to stop when they are left.
+type screenCloser interface {
+	Close()
+}
+
 // ScreenHost allows screens to request a screen change (navigation).
 type ScreenHost interface {
 	SetScreen(Screen)
@@ -27,8 +34,11 @@ type ScreenHost interface {
 const (
 	debugWindowX      = 0
 	debugWindowY      = 0
-	debugWindowWidth  = 100
-	debugWindowHeight = 100
+	debugWindowWidth  = 180
+	debugWindowHeight = 140
+
+	// percent converts fractions to percentages.
+	percent = 100
 )
 
 // screenHost owns the currently active screen and the debug UI instance.
@@ -45,8 +55,12 @@ func NewScreenHost() *screenHost {
 	return &screenHost{}
 }
 
-// SetScreen changes the currently active screen.
+// SetScreen changes the currently active screen, closing the previous one
+// if it implements screenCloser.
 func (h *screenHost) SetScreen(s Screen) {
+	if c, ok := h.current.(screenCloser); ok && h.current != s {
+		c.Close()
+	}
 	h.current = s
 }
 
@@ -59,6 +73,11 @@ func (h *screenHost) Update() error {
 			msgFps := fmt.Sprintf("FPS: %.2f", ebiten.ActualFPS())
 			ctx.Text(msgTps)
 			ctx.Text(msgFps)
+
+			// Transposition table shared by the search AIs.
+			tt := ai_models.DefaultTranspositionTable().Stats()
+			ctx.Text(fmt.Sprintf("TT hits: %d/%d (%.1f%%)", tt.Hits, tt.Probes, tt.HitRate()*percent))
+			ctx.Text(fmt.Sprintf("TT fill: %d/%d", tt.Used, tt.Capacity))
 		})
 		return nil
 	}); err != nil {
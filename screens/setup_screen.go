@@ -3,6 +3,7 @@ package screens
 import (
 	"GoTicTacToe/ai_models"
 	"GoTicTacToe/assets"
+	"GoTicTacToe/game"
 	"GoTicTacToe/ui"
 	uiutils "GoTicTacToe/ui/utils"
 	"fmt"
@@ -14,7 +15,9 @@ import (
 
 // playerCardButtons groups all the interactive buttons associated with a single player card.
 type playerCardButtons struct {
-	role       *ui.Button // Button to cycle through player roles (Human/AI Easy/AI Hard)
+	role       *ui.Button // Button to cycle through player roles (Human/AI)
+	levelDown  *ui.Button // Button to lower the AI skill level (nil for humans)
+	levelUp    *ui.Button // Button to raise the AI skill level (nil for humans)
 	symbolPrev *ui.Button // Button to select the previous symbol
 	symbolNext *ui.Button // Button to select the next symbol
 	ready      *ui.Button // Button to toggle the player's ready state
@@ -29,6 +32,7 @@ type SetupScreen struct {
 	playerCards   []*ui.PlayerCardView // Visual cards displaying player info
 	playerButtons []playerCardButtons  // Button groups for each player
 	addPlayerBtn  *ui.Button           // Button to add a new player
+	deadDrawBtn   *ui.Button           // Button to toggle dead draw detection
 	startBtn      *ui.Button           // Button to start the game
 	root          *ui.Container        // Root UI container for layout
 	background    *ebiten.Image        // Cached gradient background
@@ -63,11 +67,11 @@ var playerPalette = []color.RGBA{
 }
 
 // playerSymbolOrder defines the order in which symbols can be cycled.
-var playerSymbolOrder = []assets.SymbolType{
-	assets.CircleSymbol,
-	assets.CrossSymbol,
-	assets.TriangleSymbol,
-	assets.SquareSymbol,
+var playerSymbolOrder = []game.SymbolType{
+	game.CircleSymbol,
+	game.CrossSymbol,
+	game.TriangleSymbol,
+	game.SquareSymbol,
 }
 
 // NewSetupScreen creates a new setup screen with the given base configuration.
@@ -195,6 +199,12 @@ func (s *SetupScreen) buildGridControls() {
 			50, 40, buttonRadius, uiutils.DefaultWidgetStyle,
 			func() { s.changeToWin(+1) }),
 	)
+
+	// Rule toggles (between the grid controls and the player cards)
+	s.deadDrawBtn = ui.NewButton("", 45, controlY+60, uiutils.AnchorCenter,
+		260, 40, buttonRadius, uiutils.DefaultWidgetStyle,
+		func() { s.toggleDeadDraw() })
+	s.buttons = append(s.buttons, s.deadDrawBtn)
 }
 
 // buildPlayerCards creates the player cards and their associated control buttons.
@@ -255,8 +265,26 @@ func (s *SetupScreen) buildPlayerCards() {
 			symbolNext: symbolNext,
 			ready:      readyBtn,
 		}
-
 		s.buttons = append(s.buttons, roleBtn, symbolPrev, symbolNext, readyBtn)
+
+		// Skill level buttons (right of the subtitle, AI players only)
+		if s.config.Players[i].IsAI {
+			levelDown := ui.NewButton("-", cx+cardWidth/2-90, cy-cardHeight/2+58, uiutils.AnchorCenter,
+				40, 28, buttonRadius, uiutils.TransparentWidgetStyle,
+				func(idx int) func() {
+					return func() { s.changeLevel(idx, -1) }
+				}(i),
+			)
+			levelUp := ui.NewButton("+", cx+cardWidth/2-40, cy-cardHeight/2+58, uiutils.AnchorCenter,
+				40, 28, buttonRadius, uiutils.TransparentWidgetStyle,
+				func(idx int) func() {
+					return func() { s.changeLevel(idx, +1) }
+				}(i),
+			)
+			s.playerButtons[i].levelDown = levelDown
+			s.playerButtons[i].levelUp = levelUp
+			s.buttons = append(s.buttons, levelDown, levelUp)
+		}
 	}
 }
 
@@ -367,6 +395,12 @@ func (s *SetupScreen) changeToWin(delta int) {
 	s.config.ToWin = clampToWin(s.config.ToWin+delta, s.config.BoardWidth, s.config.BoardHeight)
 }
 
+// toggleDeadDraw turns early detection of dead draws on or off.
+func (s *SetupScreen) toggleDeadDraw() {
+	s.config.DeadDrawDetection = !s.config.DeadDrawDetection
+	s.refreshLabels()
+}
+
 // cardCenter calculates the center position for a player card at the given index.
 func (s *SetupScreen) cardCenter(idx int) (float64, float64) {
 	col := idx % cardsPerRow
@@ -378,42 +412,38 @@ func (s *SetupScreen) cardCenter(idx int) (float64, float64) {
 	return offsetX, offsetY
 }
 
-// cycleRole cycles through player roles: Human -> AI Easy -> AI Hard -> Remove (or back to Human if last player).
+// cycleRole cycles through player roles: Human -> AI -> Remove (or back to Human if last player).
 func (s *SetupScreen) cycleRole(idx int) {
 	pc := &s.config.Players[idx]
 
-	// Determine current role state
-	state := "human"
-	if pc.IsAI {
-		switch pc.AIModel.(type) {
-		case ai_models.MinimaxAI:
-			state = "ai-hard"
-		default:
-			state = "ai-easy"
-		}
-	}
-
-	// Cycle to next role
-	switch state {
-	case "human":
+	if !pc.IsAI {
 		pc.IsAI = true
 		pc.Ready = true
-		pc.AIModel = ai_models.RandomAI{}
-	case "ai-easy":
-		pc.IsAI = true
-		pc.Ready = true
-		pc.AIModel = ai_models.MinimaxAI{}
-	case "ai-hard":
-		if len(s.config.Players) <= 1 {
-			// Can't remove the last player, cycle back to human
-			pc.IsAI = false
-			pc.Ready = false
-			pc.AIModel = nil
-		} else {
-			s.removePlayer(idx)
-			return
-		}
+		pc.AIModel = nil
+	} else if len(s.config.Players) <= 1 {
+		// Can't remove the last player, cycle back to human
+		pc.IsAI = false
+		pc.Ready = false
+		pc.AIModel = nil
+	} else {
+		s.removePlayer(idx)
+		return
 	}
+
+	// Rebuild the buttons so that the level controls follow the role
+	s.init()
+	s.refreshLabels()
+}
+
+// changeLevel adjusts the AI skill level of the player by delta, clamping to
+// valid bounds.
+func (s *SetupScreen) changeLevel(idx int, delta int) {
+	pc := &s.config.Players[idx]
+	level := ai_models.SkillAI{Level: pc.Level}.EffectiveLevel()
+	pc.Level = min(max(level+delta, ai_models.MinSkillLevel), ai_models.MaxSkillLevel)
+
+	// The level replaces any model picked elsewhere
+	pc.AIModel = nil
 	s.refreshLabels()
 }
 
@@ -435,11 +465,6 @@ func (s *SetupScreen) removePlayer(idx int) {
 func (s *SetupScreen) toggleReady(idx int) {
 	pc := &s.config.Players[idx]
 	pc.Ready = !pc.Ready
-
-	// Ensure AI players have a model assigned
-	if pc.IsAI && pc.AIModel == nil {
-		pc.AIModel = ai_models.RandomAI{}
-	}
 	s.refreshLabels()
 }
 
@@ -526,7 +551,7 @@ func (s *SetupScreen) refreshLabels() {
 		if i < len(s.playerCards) && s.playerCards[i] != nil {
 			s.playerCards[i].UpdateFromConfig(ui.PlayerCardConfig{
 				Name:     playerName,
-				Subtitle: s.roleLabel(pc),
+				Subtitle: s.subtitle(pc),
 				Symbol:   pc.Symbol,
 				Color:    playerColor,
 				Ready:    pc.Ready,
@@ -544,6 +569,15 @@ func (s *SetupScreen) refreshLabels() {
 		s.addPlayerBtn.Label = fmt.Sprintf("+ Add Player (%d/%d)", len(s.config.Players), maxPlayers)
 	}
 
+	// Update rule toggle labels
+	if s.deadDrawBtn != nil {
+		state := "Off"
+		if s.config.DeadDrawDetection {
+			state = "On"
+		}
+		s.deadDrawBtn.Label = "Dead draws: " + state
+	}
+
 	// Update start button style based on whether game can start
 	if s.startBtn != nil {
 		if s.canStartGame() {
@@ -557,16 +591,23 @@ func (s *SetupScreen) refreshLabels() {
 // roleLabel returns a human-readable label for the player's current role.
 func (s *SetupScreen) roleLabel(pc PlayerConfig) string {
 	if pc.IsAI {
-		switch pc.AIModel.(type) {
-		case ai_models.MinimaxAI:
-			return "AI (Hard)"
-		default:
-			return "AI (Easy)"
-		}
+		return "AI"
 	}
 	return "Human"
 }
 
+// subtitle returns the card subtitle of the player: its role, with the skill
+// level (or the model name) for an AI player.
+func (s *SetupScreen) subtitle(pc PlayerConfig) string {
+	if !pc.IsAI {
+		return s.roleLabel(pc)
+	}
+	if pc.AIModel != nil {
+		return "AI: " + aiModelName(pc.AIModel)
+	}
+	return fmt.Sprintf("AI level %d", ai_models.SkillAI{Level: pc.Level}.EffectiveLevel())
+}
+
 // canStartGame returns true if all conditions are met to start a game:
 // - At least 2 players
 // - All players are ready
@@ -587,7 +628,13 @@ func (s *SetupScreen) startGame() {
 	if !s.canStartGame() {
 		return
 	}
-	s.host.SetScreen(NewGameScreen(s.host, s.config))
+
+	// Slow down AI-vs-AI games so they can be watched.
+	cfg := s.config
+	if cfg.onlyAI() && cfg.AIMinDelay == 0 {
+		cfg.AIMinDelay = aiVsAIMinDelay
+	}
+	s.host.SetScreen(NewGameScreen(s.host, cfg))
 }
 
 // colorsEqual compares two colors for equality by their RGBA components.
@@ -29,7 +29,7 @@ const (
 	buttonSpacing    = float64(20)
 	buttonYOffset    = float64(140)
 	buttonPaneWidth  = float64(720)
-	buttonPaneHeight = float64(200)
+	buttonPaneHeight = float64(280)
 )
 
 // Start screen layout / styling constants.
@@ -88,7 +88,7 @@ func NewStartScreen(h ScreenHost) *StartScreen {
 			func() {
 				cfg := DefaultGameConfig()
 				cfg.Players[1].IsAI = true
-				cfg.Players[1].AIModel = ai_models.MinimaxAI{}
+				cfg.Players[1].Level = ai_models.MaxSkillLevel
 				h.SetScreen(NewGameScreen(h, cfg))
 			},
 		),
@@ -101,6 +101,15 @@ func NewStartScreen(h ScreenHost) *StartScreen {
 				h.SetScreen(NewSetupScreen(h, DefaultGameConfig()))
 			},
 		),
+
+		ui.NewButton("Puzzles", 0, buttonHeight+buttonSpacing,
+			uiutils.AnchorCenter,
+			buttonWidth, buttonHeight, buttonRadius,
+			uiutils.DefaultWidgetStyle,
+			func() {
+				h.SetScreen(NewPuzzleScreen(h))
+			},
+		),
 	}
 
 	for _, btn := range s.buttons {
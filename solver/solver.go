@@ -0,0 +1,312 @@
+/**
+ ******************************************************************************
+ * @file            : solver.go
+ * @brief           : GoTicTacToe - Retrograde solver
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements the solver itself: a memoized depth-first search over
+ * every position reachable from the empty board, where positions are merged
+ * with their symmetric variants (see game.Symmetries).
+ *
+ * Each position is stored with its outcome under perfect play and the
+ * number of plies until the game ends. With more than two players, every
+ * player maximizes its own result (win > draw > loss), preferring quick wins
+ * and slow losses.
+ ******************************************************************************
+ */
+
+// Package solver computes exact game-theoretic values of small board
+// configurations.
+package solver
+
+import (
+	"GoTicTacToe/game"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Outcome is the result of a position under perfect play, from the point of
+// view of the side to move.
+//
+// Values 0..Players-1 give the winner as an offset in turn order from the
+// side to move: 0 means the side to move wins, 1 the next player, etc. In a
+// two-player game, 0 is a win and 1 a loss.
+type Outcome uint8
+
+const (
+	// OutcomeWin means the side to move wins (offset 0).
+	OutcomeWin Outcome = 0
+
+	// OutcomeDraw means the game ends in a draw.
+	OutcomeDraw Outcome = math.MaxUint8
+)
+
+// Entry is the solved value of a position.
+type Entry struct {
+	Outcome  Outcome // Result under perfect play
+	Distance uint8   // Plies until the end of the game under perfect play
+}
+
+// Config identifies a solvable configuration.
+type Config struct {
+	Width   int // Number of columns
+	Height  int // Number of rows
+	ToWin   int // Required aligned symbols to win
+	Players int // Number of players (the first player moves first)
+}
+
+// String returns the configuration in position notation style ("4x4/3, 2p").
+func (c Config) String() string {
+	return fmt.Sprintf("%dx%d/%d, %dp", c.Width, c.Height, c.ToWin, c.Players)
+}
+
+// Options tunes a Solve call.
+type Options struct {
+	// MaxPositions aborts the search with ErrTooLarge once more positions
+	// have been stored (0 means no limit).
+	MaxPositions int
+
+	// Prune stops exploring a position as soon as the side to move finds a
+	// winning move. Outcomes stay exact, but fewer positions are stored and
+	// the distance of won positions is only an upper bound. Use it to get the
+	// result of configurations too large for an exhaustive table.
+	Prune bool
+}
+
+// Errors returned by Solve.
+var (
+	// ErrTooLarge is returned when Options.MaxPositions is exceeded.
+	ErrTooLarge = errors.New("solver: too many positions")
+
+	// ErrUnsupported is returned for configurations the solver cannot encode.
+	ErrUnsupported = errors.New("solver: unsupported configuration")
+)
+
+// maxDistance is the largest distance an Entry can hold.
+const maxDistance = math.MaxUint8
+
+// solver holds the state of a single Solve call.
+type solver struct {
+	cfg     Config
+	opts    Options
+	target  int
+	cells   []uint8 // Cell contents: 0 = empty, k = player k-1
+	perms   [][]int // Cell permutation of each board symmetry
+	pow     []uint64
+	entries map[uint64]Entry
+}
+
+// Solve solves every position reachable from the empty board of cfg.
+func Solve(cfg Config, opts Options) (*Table, error) {
+	s, err := newSolver(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.search(0); err != nil {
+		return nil, err
+	}
+
+	return &Table{Config: cfg, Pruned: opts.Prune, entries: s.entries, perms: s.perms, pow: s.pow}, nil
+}
+
+// newSolver validates cfg and precomputes symmetry permutations.
+func newSolver(cfg Config, opts Options) (*solver, error) {
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.ToWin < 1 || cfg.Players < 1 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, cfg)
+	}
+	if cfg.Players >= int(OutcomeDraw) {
+		return nil, fmt.Errorf("%w: too many players", ErrUnsupported)
+	}
+
+	pow, err := powers(cfg.Width*cfg.Height, cfg.Players+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &solver{
+		cfg:     cfg,
+		opts:    opts,
+		target:  min(cfg.ToWin, cfg.Width, cfg.Height),
+		cells:   make([]uint8, cfg.Width*cfg.Height),
+		perms:   symmetryPerms(cfg.Width, cfg.Height),
+		pow:     pow,
+		entries: map[uint64]Entry{},
+	}, nil
+}
+
+// search returns the value of the current position, ply tokens into the game.
+// The last move did not end the game.
+func (s *solver) search(ply int) (Entry, error) {
+	code := canonicalCode(s.cells, s.perms, s.pow)
+	if e, ok := s.entries[code]; ok {
+		return e, nil
+	}
+
+	n := s.cfg.Players
+	mover := uint8(ply%n) + 1
+
+	best := Entry{Outcome: OutcomeDraw}
+	found := false
+
+	for i := range s.cells {
+		if s.cells[i] != 0 {
+			continue
+		}
+
+		s.cells[i] = mover
+		var child Entry
+		switch {
+		case s.wins(i, mover):
+			child = Entry{Outcome: OutcomeWin, Distance: 1}
+		case ply+1 == len(s.cells):
+			child = Entry{Outcome: OutcomeDraw, Distance: 1}
+		default:
+			sub, err := s.search(ply + 1)
+			if err != nil {
+				s.cells[i] = 0
+				return Entry{}, err
+			}
+			child = fromChild(sub, n)
+		}
+		s.cells[i] = 0
+
+		if !found || better(child, best) {
+			best, found = child, true
+		}
+		if s.opts.Prune && best.Outcome == OutcomeWin {
+			break
+		}
+	}
+
+	if s.opts.MaxPositions > 0 && len(s.entries) >= s.opts.MaxPositions {
+		return Entry{}, fmt.Errorf("%w: more than %d", ErrTooLarge, s.opts.MaxPositions)
+	}
+	s.entries[code] = best
+	return best, nil
+}
+
+// fromChild converts the value of a child position (whose side to move is the
+// next player) into the value of the move leading to it.
+func fromChild(child Entry, players int) Entry {
+	out := Entry{Outcome: OutcomeDraw, Distance: child.Distance}
+	if out.Distance < maxDistance {
+		out.Distance++
+	}
+	if child.Outcome != OutcomeDraw {
+		out.Outcome = Outcome((int(child.Outcome) + 1) % players)
+	}
+	return out
+}
+
+// better reports whether the side to move prefers a over b.
+//
+// Wins beat draws, which beat losses. Among wins the shortest is preferred,
+// among draws and losses the longest (to give opponents chances to err).
+func better(a, b Entry) bool {
+	ra, rb := rank(a.Outcome), rank(b.Outcome)
+	if ra != rb {
+		return ra > rb
+	}
+	if a.Outcome == OutcomeWin {
+		return a.Distance < b.Distance
+	}
+	return a.Distance > b.Distance
+}
+
+// rank orders outcomes for the side to move: win (2) > draw (1) > loss (0).
+func rank(o Outcome) int {
+	switch o {
+	case OutcomeWin:
+		return 2
+	case OutcomeDraw:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// wins reports whether the token just placed at cell idx completes a line.
+func (s *solver) wins(idx int, who uint8) bool {
+	return completesLine(s.cells, s.cfg.Width, s.cfg.Height, s.target, idx, who)
+}
+
+// lineDirections are the 4 directions scanned around a placed token.
+var lineDirections = [...][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+// completesLine reports whether cell idx belongs to a line of target tokens of who.
+// Cells are stored column-major (index = x*height + y), like game.Board.Cells.
+func completesLine(cells []uint8, width, height, target, idx int, who uint8) bool {
+	x0, y0 := idx/height, idx%height
+
+	for _, d := range lineDirections {
+		count := 1
+		for _, sign := range [2]int{1, -1} {
+			x, y := x0+sign*d[0], y0+sign*d[1]
+			for x >= 0 && y >= 0 && x < width && y < height && cells[x*height+y] == who {
+				count++
+				x += sign * d[0]
+				y += sign * d[1]
+			}
+		}
+		if count >= target {
+			return true
+		}
+	}
+	return false
+}
+
+// powers returns base^i for every cell index, or ErrUnsupported if the
+// position code (at most base^cells - 1) would not fit into 64 bits.
+func powers(cells, base int) ([]uint64, error) {
+	pow := make([]uint64, cells)
+	v := uint64(1)
+	for i := range pow {
+		pow[i] = v
+		hi, lo := bits.Mul64(v, uint64(base))
+		if hi != 0 {
+			return nil, fmt.Errorf("%w: %d cells with %d players do not fit in a 64-bit code",
+				ErrUnsupported, cells, base-1)
+		}
+		v = lo
+	}
+	return pow, nil
+}
+
+// symmetryPerms returns, for each symmetry of the board, the permutation p
+// such that cell p[i] of the original board lands on cell i.
+func symmetryPerms(width, height int) [][]int {
+	syms := game.Symmetries(width, height)
+	perms := make([][]int, len(syms))
+	for k, sym := range syms {
+		perm := make([]int, width*height)
+		for x := 0; x < width; x++ {
+			for y := 0; y < height; y++ {
+				m := sym.Apply(game.Move{X: x, Y: y}, width, height)
+				perm[m.X*height+m.Y] = x*height + y
+			}
+		}
+		perms[k] = perm
+	}
+	return perms
+}
+
+// canonicalCode returns the smallest code among the symmetric variants of cells.
+func canonicalCode(cells []uint8, perms [][]int, pow []uint64) uint64 {
+	best := uint64(math.MaxUint64)
+	for _, perm := range perms {
+		var code uint64
+		for i, src := range perm {
+			code += uint64(cells[src]) * pow[i]
+		}
+		best = min(best, code)
+	}
+	return best
+}
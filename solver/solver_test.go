@@ -0,0 +1,187 @@
+package solver
+
+import (
+	"GoTicTacToe/game"
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// tictactoe is the classic configuration, small enough to solve in every test.
+var tictactoe = Config{Width: 3, Height: 3, ToWin: 3, Players: 2}
+
+// solve solves cfg or fails the test.
+func solve(t *testing.T, cfg Config, opts Options) *Table {
+	t.Helper()
+	table, err := Solve(cfg, opts)
+	if err != nil {
+		t.Fatalf("Solve(%s): %v", cfg, err)
+	}
+	return table
+}
+
+// decodeGame returns a game set up from a position notation.
+func decodeGame(t *testing.T, notation string) *game.Game {
+	t.Helper()
+	g := game.NewGame()
+	if err := g.Decode(notation); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestSolveKnownResults(t *testing.T) {
+	tests := []struct {
+		cfg  Config
+		want Entry
+	}{
+		{cfg: tictactoe, want: Entry{Outcome: OutcomeDraw, Distance: 9}},
+		{cfg: Config{Width: 3, Height: 3, ToWin: 2, Players: 2}, want: Entry{Outcome: OutcomeWin, Distance: 3}},
+		{cfg: Config{Width: 1, Height: 1, ToWin: 1, Players: 2}, want: Entry{Outcome: OutcomeWin, Distance: 1}},
+		{cfg: Config{Width: 4, Height: 4, ToWin: 3, Players: 2}, want: Entry{Outcome: OutcomeWin, Distance: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cfg.String(), func(t *testing.T) {
+			if testing.Short() && tt.cfg.Width*tt.cfg.Height > 9 {
+				t.Skip("large configuration")
+			}
+			if got := solve(t, tt.cfg, Options{}).Root(); got != tt.want {
+				t.Errorf("Root() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolveTooLarge(t *testing.T) {
+	if _, err := Solve(tictactoe, Options{MaxPositions: 100}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Solve with 100 positions at most = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestSolvePrune(t *testing.T) {
+	cfg := Config{Width: 4, Height: 4, ToWin: 3, Players: 2}
+	table := solve(t, cfg, Options{Prune: true})
+
+	root := table.Root()
+	if !table.Pruned || root.Outcome != OutcomeWin || root.Distance < 5 {
+		t.Errorf("pruned solve: Pruned %v, Root() %+v, want a win in at least 5 plies", table.Pruned, root)
+	}
+	// The full table of 4x4/3 holds 434205 positions.
+	if table.Len() >= 434205 {
+		t.Errorf("pruned table holds %d positions, no fewer than the full one", table.Len())
+	}
+}
+
+func TestTableWriteRead(t *testing.T) {
+	for _, opts := range []Options{{}, {Prune: true}} {
+		table := solve(t, tictactoe, opts)
+
+		var buf bytes.Buffer
+		if err := table.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+
+		got, err := Read(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		if got.Config != table.Config || got.Pruned != table.Pruned {
+			t.Errorf("read %s (pruned %v), want %s (pruned %v)", got.Config, got.Pruned, table.Config, table.Pruned)
+		}
+		if !reflect.DeepEqual(got.entries, table.entries) {
+			t.Errorf("read %d entries differing from the %d written", got.Len(), table.Len())
+		}
+
+		for _, bad := range [][]byte{nil, []byte("GTTA"), data[:len(data)-1]} {
+			if _, err := Read(bytes.NewReader(bad)); !errors.Is(err, ErrInvalidTable) {
+				t.Errorf("Read of %d bytes = %v, want ErrInvalidTable", len(bad), err)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	table := solve(t, tictactoe, Options{})
+
+	tests := []struct {
+		name     string
+		position string // Position as written by game.Game.Encode
+		want     Entry
+	}{
+		{name: "centre", position: "3x3/3 3/1a1/3 b 2 -", want: Entry{Outcome: OutcomeDraw, Distance: 8}},
+		// Answering the centre on an edge loses.
+		{name: "edge reply", position: "3x3/3 1b1/1a1/3 a 2 -", want: Entry{Outcome: OutcomeWin, Distance: 5}},
+		{name: "edge reply, b started", position: "3x3/3 1a1/1b1/3 b 2 -", want: Entry{Outcome: OutcomeWin, Distance: 5}},
+		{name: "edge reply, mirrored", position: "3x3/3 3/1a1/1b1 a 2 -", want: Entry{Outcome: OutcomeWin, Distance: 5}},
+		{name: "must block", position: "3x3/3 aa1/1b1/3 b 2 -", want: Entry{Outcome: OutcomeDraw, Distance: 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := decodeGame(t, tt.position)
+			got, ok := table.Lookup(g.Board, g.Current.ID)
+			if !ok || got != tt.want {
+				t.Errorf("Lookup = %+v, %v; want %+v", got, ok, tt.want)
+			}
+		})
+	}
+
+	g := decodeGame(t, "3x3/3 3/1a1/3 b 2 -")
+	if _, ok := table.Lookup(g.Board, 2); ok {
+		t.Error("Lookup accepted a side to move beyond the players")
+	}
+	if _, ok := table.Lookup(game.NewBoard(4, 4, 3), 0); ok {
+		t.Error("Lookup accepted a board of another configuration")
+	}
+}
+
+func TestBestMoveKeepsValue(t *testing.T) {
+	table := solve(t, tictactoe, Options{})
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		g := game.NewGame()
+		for plies := rng.Intn(8); plies > 0 && g.State == game.PLAYING; plies-- {
+			moves := g.Board.AvailableMoves()
+			m := moves[rng.Intn(len(moves))]
+			g.PlayMove(m.X, m.Y)
+		}
+		if g.State != game.PLAYING {
+			continue
+		}
+
+		want, ok := table.Lookup(g.Board, g.Current.ID)
+		if !ok {
+			t.Fatalf("%s: position not in the table", g.Encode())
+		}
+		m, got, ok := table.BestMove(g.Board, g.Current.ID)
+		if !ok || got != want {
+			t.Fatalf("%s: BestMove value %+v, %v; want %+v", g.Encode(), got, ok, want)
+		}
+
+		// Playing the move leaves the opponent the value it promised.
+		position := g.Encode()
+		if !g.PlayMove(m.X, m.Y) {
+			t.Fatalf("%s: BestMove %v is illegal", position, m)
+		}
+		switch {
+		case g.Winner != nil:
+			if want.Outcome != OutcomeWin || want.Distance != 1 {
+				t.Errorf("%s: BestMove %v wins at once, value %+v", position, m, want)
+			}
+		case g.State != game.PLAYING:
+			if want != (Entry{Outcome: OutcomeDraw, Distance: 1}) {
+				t.Errorf("%s: BestMove %v fills the board, value %+v", position, m, want)
+			}
+		default:
+			child, _ := table.Lookup(g.Board, g.Current.ID)
+			if got := fromChild(child, tictactoe.Players); got != want {
+				t.Errorf("%s: BestMove %v leads to %+v, want %+v", position, m, got, want)
+			}
+		}
+	}
+}
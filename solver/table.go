@@ -0,0 +1,274 @@
+/**
+ ******************************************************************************
+ * @file            : table.go
+ * @brief           : GoTicTacToe - Solver table and file format
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements Table, the result of a solve, with position lookup,
+ * perfect move selection and a compact binary file format:
+ *
+ *   magic "GTTB", version, width, height, toWin, players, flags (1 byte each)
+ *   entry count (uvarint)
+ *   entries sorted by code: code delta (uvarint), outcome, distance
+ ******************************************************************************
+ */
+
+package solver
+
+import (
+	"GoTicTacToe/game"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// Table file format constants.
+const (
+	tableMagic   = "GTTB"
+	tableVersion = 1
+
+	// tableFlagPruned marks a table produced with Options.Prune.
+	tableFlagPruned = 1 << 0
+)
+
+// ErrInvalidTable is returned when a table file cannot be decoded.
+var ErrInvalidTable = errors.New("solver: invalid table file")
+
+// Table holds the solved positions of a configuration.
+type Table struct {
+	Config Config
+	Pruned bool // Whether the table was built with Options.Prune
+
+	entries map[uint64]Entry
+	perms   [][]int
+	pow     []uint64
+}
+
+// Len returns the number of stored positions.
+func (t *Table) Len() int {
+	return len(t.entries)
+}
+
+// Root returns the value of the empty board.
+func (t *Table) Root() Entry {
+	return t.entries[0]
+}
+
+// Lookup returns the value of the position on board for the player whose ID
+// is toMove.
+//
+// The table assumes turns follow player IDs (0, 1, ... wrapping around), but
+// not that player 0 started: tokens are relabeled from the number of tokens
+// on the board and the side to move.
+//
+// It returns false if the board does not match the table configuration or
+// the position is not in the table (e.g. already finished, or skipped by a
+// pruned solve).
+func (t *Table) Lookup(board *game.Board, toMove int) (Entry, bool) {
+	cells, _, ok := t.cellsOf(board, toMove)
+	if !ok {
+		return Entry{}, false
+	}
+	e, ok := t.entries[canonicalCode(cells, t.perms, t.pow)]
+	return e, ok
+}
+
+// BestMove returns a move that achieves the table value of the position for
+// the player whose ID is toMove, and that value (see Lookup).
+//
+// It returns false if the position is unknown or has no move left.
+func (t *Table) BestMove(board *game.Board, toMove int) (game.Move, Entry, bool) {
+	cells, ply, ok := t.cellsOf(board, toMove)
+	if !ok {
+		return game.Move{}, Entry{}, false
+	}
+
+	mover := uint8(ply%t.Config.Players) + 1
+	height := t.Config.Height
+	target := min(t.Config.ToWin, t.Config.Width, t.Config.Height)
+
+	var best Entry
+	bestIdx := -1
+	for i := range cells {
+		if cells[i] != 0 {
+			continue
+		}
+
+		cells[i] = mover
+		var child Entry
+		known := true
+		switch {
+		case completesLine(cells, t.Config.Width, height, target, i, mover):
+			child = Entry{Outcome: OutcomeWin, Distance: 1}
+		case ply+1 == len(cells):
+			child = Entry{Outcome: OutcomeDraw, Distance: 1}
+		default:
+			var sub Entry
+			sub, known = t.entries[canonicalCode(cells, t.perms, t.pow)]
+			child = fromChild(sub, t.Config.Players)
+		}
+		cells[i] = 0
+
+		if known && (bestIdx < 0 || better(child, best)) {
+			best, bestIdx = child, i
+		}
+	}
+
+	if bestIdx < 0 {
+		return game.Move{}, Entry{}, false
+	}
+	return game.Move{X: bestIdx / height, Y: bestIdx % height}, best, true
+}
+
+// cellsOf converts a board to the solver's cell layout, relabeling players so
+// that toMove gets the label of the side to move in the table, and returns
+// the number of tokens on the board.
+func (t *Table) cellsOf(board *game.Board, toMove int) ([]uint8, int, bool) {
+	n := t.Config.Players
+	if board.Width != t.Config.Width || board.Height != t.Config.Height ||
+		min(board.ToWin, board.Width, board.Height) != min(t.Config.ToWin, t.Config.Width, t.Config.Height) ||
+		toMove < 0 || toMove >= n {
+		return nil, 0, false
+	}
+
+	ply := board.Width*board.Height - board.EmptyCount()
+	shift := ply%n - toMove
+
+	cells := make([]uint8, board.Width*board.Height)
+	for x := 0; x < board.Width; x++ {
+		for y := 0; y < board.Height; y++ {
+			p := board.Cells[x][y]
+			if p == nil {
+				continue
+			}
+			if p.ID < 0 || p.ID >= n {
+				return nil, 0, false
+			}
+			cells[x*board.Height+y] = uint8((p.ID+shift+n)%n) + 1
+		}
+	}
+	return cells, ply, true
+}
+
+// Write serializes the table to w in the compact binary format.
+func (t *Table) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	flags := byte(0)
+	if t.Pruned {
+		flags |= tableFlagPruned
+	}
+	bw.WriteString(tableMagic)
+	bw.Write([]byte{
+		tableVersion,
+		byte(t.Config.Width), byte(t.Config.Height), byte(t.Config.ToWin), byte(t.Config.Players),
+		flags,
+	})
+
+	codes := make([]uint64, 0, len(t.entries))
+	for code := range t.entries {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	var buf [binary.MaxVarintLen64]byte
+	bw.Write(buf[:binary.PutUvarint(buf[:], uint64(len(codes)))])
+
+	prev := uint64(0)
+	for _, code := range codes {
+		e := t.entries[code]
+		bw.Write(buf[:binary.PutUvarint(buf[:], code-prev)])
+		bw.Write([]byte{byte(e.Outcome), e.Distance})
+		prev = code
+	}
+
+	return bw.Flush()
+}
+
+// WriteFile saves the table to the file at path.
+func (t *Table) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := t.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read loads a table written by Table.Write.
+func Read(r io.Reader) (*Table, error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, len(tableMagic)+6)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTable, err)
+	}
+	if string(header[:len(tableMagic)]) != tableMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidTable)
+	}
+	h := header[len(tableMagic):]
+	if h[0] != tableVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidTable, h[0])
+	}
+
+	cfg := Config{Width: int(h[1]), Height: int(h[2]), ToWin: int(h[3]), Players: int(h[4])}
+	s, err := newSolver(cfg, Options{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTable, err)
+	}
+
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("%w: entry count: %v", ErrInvalidTable, err)
+	}
+
+	entries := make(map[uint64]Entry, count)
+	code := uint64(0)
+	pair := make([]byte, 2)
+	for i := uint64(0); i < count; i++ {
+		delta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %v", ErrInvalidTable, i, err)
+		}
+		if _, err := io.ReadFull(br, pair); err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %v", ErrInvalidTable, i, err)
+		}
+		code += delta
+
+		outcome := Outcome(pair[0])
+		if outcome != OutcomeDraw && int(outcome) >= cfg.Players {
+			return nil, fmt.Errorf("%w: entry %d: invalid outcome %d", ErrInvalidTable, i, outcome)
+		}
+		entries[code] = Entry{Outcome: outcome, Distance: pair[1]}
+	}
+
+	return &Table{
+		Config:  cfg,
+		Pruned:  h[5]&tableFlagPruned != 0,
+		entries: entries,
+		perms:   s.perms,
+		pow:     s.pow,
+	}, nil
+}
+
+// ReadFile loads the table stored in the file at path.
+func ReadFile(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
@@ -52,7 +52,8 @@ const (
 type BoardView struct {
 	Widget // Embeds Widget: inherits size, position, anchor, LayoutRect(), etc.
 
-	logicBoard  *game.Board      // Reference to the logical board
+	logicBoard  *game.Board      // Board being drawn (see SetBoard)
+	visuals     PlayerVisuals    // How each player's symbol is drawn
 	OnCellClick func(cx, cy int) // Callback triggered when a cell is clicked
 
 	lastGridW int // Cached grid image width
@@ -63,12 +64,14 @@ type BoardView struct {
 //
 // Parameters:
 // - board: logical board reference (game state)
+// - visuals: symbol image and color of each player
 // - x, y: offset (relative to the widget anchor)
 // - size: widget width and height (square board rendering)
 // - style: visual styling (background, border, etc.)
 // - onClick: callback invoked when a cell is clicked (grid coordinates)
 func NewBoardView(
 	board *game.Board,
+	visuals PlayerVisuals,
 	x, y, size float64,
 	style utils.WidgetStyle,
 	onClick func(cx, cy int),
@@ -83,6 +86,7 @@ func NewBoardView(
 			Style:   style,
 		},
 		logicBoard:  board,
+		visuals:     visuals,
 		OnCellClick: onClick,
 	}
 
@@ -92,6 +96,12 @@ func NewBoardView(
 	return view
 }
 
+// SetBoard replaces the board being drawn, typically with the board of the
+// latest game.Snapshot. It must have the same dimensions as the previous one.
+func (v *BoardView) SetBoard(board *game.Board) {
+	v.logicBoard = board
+}
+
 // createGridImage renders the static background grid (background + lines)
 // and returns the resulting image.
 func (v *BoardView) createGridImage(width, height int) *ebiten.Image {
@@ -205,11 +215,16 @@ func (v *BoardView) Draw(screen *ebiten.Image) {
 	for x := 0; x < v.logicBoard.Width; x++ {
 		for y := 0; y < v.logicBoard.Height; y++ {
 			p := v.logicBoard.Cells[x][y]
-			if p == nil || p.Symbol.Image == nil {
+			if p == nil {
+				continue
+			}
+
+			visual := v.visuals.Of(p)
+			symbolImg := visual.Symbol.Image
+			if symbolImg == nil {
 				continue
 			}
 
-			symbolImg := p.Symbol.Image
 			srcWInt, srcHInt := symbolImg.Bounds().Dx(), symbolImg.Bounds().Dy()
 
 			// Determine scaling factor based on the largest symbol dimension.
@@ -234,7 +249,7 @@ func (v *BoardView) Draw(screen *ebiten.Image) {
 			opSym.GeoM.Translate(drawX, drawY)
 
 			// Tint symbol with the player's color.
-			opSym.ColorScale.ScaleWithColor(p.Color)
+			opSym.ColorScale.ScaleWithColor(visual.Color)
 
 			screen.DrawImage(symbolImg, opSym)
 		}
@@ -0,0 +1,143 @@
+/**
+ ******************************************************************************
+ * @file            : clock.go
+ * @brief           : GoTicTacToe - Chess clock widget
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements ClockView, a widget displaying the remaining time of
+ * every player in a timed match. It is laid out below ScoreView, with one
+ * zone per player in the same order.
+ ******************************************************************************
+ */
+
+package ui
+
+import (
+	"GoTicTacToe/assets"
+	"GoTicTacToe/game"
+	"GoTicTacToe/ui/utils"
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// ClockView layout constants.
+const (
+	// Panel appearance.
+	clockPanelCornerRadiusPx = 10
+
+	// clockPanelGapPx is the vertical gap between ScoreView and ClockView.
+	clockPanelGapPx = 8
+
+	// lowTimeThreshold is the remaining time below which tenths are shown
+	// and the time is highlighted.
+	lowTimeThreshold = 10 * time.Second
+
+	// secondsPerMinute is used to split a duration into minutes and seconds.
+	secondsPerMinute = 60
+)
+
+// lowTimeColor highlights a clock that is about to run out.
+var lowTimeColor = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+
+// ClockView displays the clock of every player of a timed match.
+type ClockView struct {
+	Widget
+	handle  *game.Handle
+	visuals PlayerVisuals
+}
+
+// NewClockView creates a clock panel widget.
+//
+// The widget is anchored at the top-center, right below a ScoreView of
+// height scoreHeight. It shows the clock of the game behind h.
+func NewClockView(h *game.Handle, visuals PlayerVisuals, width, height, scoreHeight float64, style utils.WidgetStyle) *ClockView {
+	bg := utils.CreateRoundedRect(int(width), int(height), clockPanelCornerRadiusPx, style.BackgroundNormal)
+
+	return &ClockView{
+		Widget: Widget{
+			Width:   width,
+			Height:  height,
+			image:   bg,
+			Anchor:  utils.AnchorTopCenter,
+			OffsetY: scorePanelOffsetY + scoreHeight + clockPanelGapPx,
+			Style:   style,
+		},
+		handle:  h,
+		visuals: visuals,
+	}
+}
+
+// Draw renders the clock panel. Nothing is drawn for untimed matches.
+func (cv *ClockView) Draw(screen *ebiten.Image) {
+	// The snapshot's clock keeps running, so the game is never locked here.
+	snap := cv.handle.Snapshot()
+	playerCount := len(snap.Players)
+	if snap.Clock == nil || playerCount == 0 {
+		return
+	}
+
+	rect := cv.LayoutRect()
+
+	op := &ebiten.DrawImageOptions{}
+	srcW := float64(cv.image.Bounds().Dx())
+	srcH := float64(cv.image.Bounds().Dy())
+	if srcW != 0 && srcH != 0 {
+		op.GeoM.Scale(rect.Width/srcW, rect.Height/srcH)
+	}
+	op.GeoM.Translate(rect.X, rect.Y)
+	screen.DrawImage(cv.image, op)
+
+	// Same zones as ScoreView so each clock sits under its player.
+	zoneWidth := rect.Width / float64(playerCount)
+
+	for i, p := range snap.Players {
+		t := snap.Clock.Time(p.ID)
+
+		opts := &text.DrawOptions{}
+		opts.PrimaryAlign = text.AlignCenter
+		opts.SecondaryAlign = text.AlignCenter
+		opts.GeoM.Translate(rect.X+(float64(i)+half)*zoneWidth, rect.Y+rect.Height*half)
+
+		switch {
+		case t.Flagged || (t.Running && t.Main < lowTimeThreshold && t.Periods == 0):
+			opts.ColorScale.ScaleWithColor(lowTimeColor)
+		case t.Running:
+			opts.ColorScale.ScaleWithColor(cv.visuals.Of(p).Color)
+		default:
+			opts.ColorScale.ScaleWithColor(cv.Style.TextColor)
+			opts.ColorScale.ScaleAlpha(nonActiveAlphaScale)
+		}
+
+		text.Draw(screen, formatPlayerTime(t), assets.NormalFont, opts)
+	}
+}
+
+// formatPlayerTime formats a clock as "m:ss" (or "s.t" when low), followed by
+// the byo-yomi state once the main time is over.
+func formatPlayerTime(t game.PlayerTime) string {
+	if t.Main > 0 || t.Periods == 0 {
+		return formatDuration(t.Main)
+	}
+	return fmt.Sprintf("%d× %s", t.Periods, formatDuration(t.Period))
+}
+
+// formatDuration formats a duration as "m:ss", or "s.t" under lowTimeThreshold.
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	if d < lowTimeThreshold {
+		return fmt.Sprintf("%.1f", d.Seconds())
+	}
+	total := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", total/secondsPerMinute, total%secondsPerMinute)
+}
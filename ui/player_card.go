@@ -28,6 +28,7 @@ package ui
 
 import (
 	"GoTicTacToe/assets"
+	"GoTicTacToe/game"
 	"GoTicTacToe/ui/utils"
 	"image/color"
 
@@ -36,9 +37,6 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
-// cardSymbolCache stores pre-rendered symbol images to avoid regenerating them.
-var cardSymbolCache = map[assets.SymbolType]*ebiten.Image{}
-
 // PlayerCardView constants (layout and visuals).
 const (
 
@@ -86,11 +84,11 @@ const (
 // PlayerCardConfig holds the configuration data for updating a player card.
 // This struct is used to pass player state from the setup screen to the card.
 type PlayerCardConfig struct {
-	Name     string            // Display name of the player
-	Subtitle string            // Secondary text (e.g., "Human" or "AI (Easy)")
-	Symbol   assets.SymbolType // The symbol type this player uses
-	Color    color.Color       // The player's display color
-	Ready    bool              // Whether the player is ready to start
+	Name     string          // Display name of the player
+	Subtitle string          // Secondary text (e.g., "Human" or "AI level 5")
+	Symbol   game.SymbolType // The symbol type this player uses
+	Color    color.Color     // The player's display color
+	Ready    bool            // Whether the player is ready to start
 }
 
 // PlayerCardView is a widget that displays player information in a card format.
@@ -100,12 +98,12 @@ type PlayerCardConfig struct {
 type PlayerCardView struct {
 	Widget // Embedded base widget providing position, size, and anchor
 
-	Title           string            // Primary text displayed at the top of the card
-	Subtitle        string            // Secondary text displayed below the title
-	CenterLabel     string            // Text displayed in the center (when ShowCenterLabel is true)
-	Color           color.Color       // Accent color for the card (used for strip and symbol)
-	Symbol          assets.SymbolType // The symbol to display in the card center
-	ShowCenterLabel bool              // If true, shows CenterLabel instead of symbol
+	Title           string          // Primary text displayed at the top of the card
+	Subtitle        string          // Secondary text displayed below the title
+	CenterLabel     string          // Text displayed in the center (when ShowCenterLabel is true)
+	Color           color.Color     // Accent color for the card (used for strip and symbol)
+	Symbol          game.SymbolType // The symbol to display in the card center
+	ShowCenterLabel bool            // If true, shows CenterLabel instead of symbol
 
 	OnSymbolClick func() // Callback invoked when the symbol area is clicked
 
@@ -308,13 +306,8 @@ func (c *PlayerCardView) colorOr(fallback color.Color) color.Color {
 
 // cachedCardSymbol retrieves or creates a cached image for the given symbol type.
 // This avoids regenerating symbol images on every frame.
-func cachedCardSymbol(sym assets.SymbolType) *ebiten.Image {
-	if img, ok := cardSymbolCache[sym]; ok && img != nil {
-		return img
-	}
-	img := assets.NewSymbol(sym).Image
-	cardSymbolCache[sym] = img
-	return img
+func cachedCardSymbol(sym game.SymbolType) *ebiten.Image {
+	return cachedSymbol(sym).Image
 }
 
 // UpdateFromConfig updates the card's display properties from a PlayerCardConfig.
@@ -0,0 +1,79 @@
+/**
+ ******************************************************************************
+ * @file            : player_visuals.go
+ * @brief           : GoTicTacToe - Player symbols and colors
+ ******************************************************************************
+ * @copyright   : Copyright (c) 2026 HEIA-FR / ISC
+ *                Haute école d'ingénierie et d'architecture de Fribourg
+ *                Informatique et Systèmes de Communication
+ * @attention   : SPDX-License-Identifier: MIT OR Apache-2.0
+ ******************************************************************************
+ * @details
+ * This file implements PlayerVisuals, the mapping from game players to the
+ * symbol image and color used to draw them. The game package only knows a
+ * player's symbol type; every widget drawing players goes through this
+ * mapping.
+ ******************************************************************************
+ */
+
+package ui
+
+import (
+	"GoTicTacToe/assets"
+	"GoTicTacToe/game"
+	"image/color"
+)
+
+// PlayerVisual describes how a single player is drawn.
+type PlayerVisual struct {
+	Symbol *assets.Symbol // Rendered symbol image
+	Color  color.Color    // Tint applied to the symbol and the player's texts
+}
+
+// PlayerVisuals maps players (by ID) to their visuals.
+//
+// Players without an entry are drawn with their symbol type in white.
+type PlayerVisuals map[int]PlayerVisual
+
+// symbolCache stores generated symbol images shared by all players.
+var symbolCache = map[game.SymbolType]*assets.Symbol{}
+
+// NewPlayerVisuals builds the visuals of players, generating each player's
+// symbol and using colors[i] (cycling) for the i-th player.
+func NewPlayerVisuals(players []*game.Player, colors []color.Color) PlayerVisuals {
+	pv := PlayerVisuals{}
+	for i, p := range players {
+		c := color.Color(color.White)
+		if len(colors) > 0 {
+			c = colors[i%len(colors)]
+		}
+		pv.Set(p, c)
+	}
+	return pv
+}
+
+// Set assigns color c to p, with the image of p's symbol type.
+func (pv PlayerVisuals) Set(p *game.Player, c color.Color) {
+	pv[p.ID] = PlayerVisual{Symbol: cachedSymbol(p.Symbol), Color: c}
+}
+
+// Of returns the visuals of p.
+func (pv PlayerVisuals) Of(p *game.Player) PlayerVisual {
+	if v, ok := pv[p.ID]; ok && v.Symbol != nil {
+		if v.Color == nil {
+			v.Color = color.White
+		}
+		return v
+	}
+	return PlayerVisual{Symbol: cachedSymbol(p.Symbol), Color: color.White}
+}
+
+// cachedSymbol retrieves or generates the symbol of the given type.
+func cachedSymbol(sym game.SymbolType) *assets.Symbol {
+	if s, ok := symbolCache[sym]; ok {
+		return s
+	}
+	s := assets.NewSymbol(sym)
+	symbolCache[sym] = s
+	return s
+}
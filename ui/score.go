@@ -23,7 +23,8 @@
 //
 //	This file implements ScoreView, a widget displaying the current scores and
 //	symbols for any number of players. Non-active players can be visually dimmed
-//	while the game is running.
+//	while the game is running, and an animated indicator shows which AI player
+//	is thinking.
 package ui
 
 import (
@@ -31,6 +32,8 @@ import (
 	"GoTicTacToe/game"
 	"GoTicTacToe/ui/utils"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -54,18 +57,31 @@ const (
 
 	// Visual effect when it's not the player's turn.
 	nonActiveAlphaScale = 0.5
+
+	// Thinking indicator: dots appear one by one, then start over.
+	thinkingDot       = "."
+	thinkingMaxDots   = 3
+	thinkingDotPeriod = 300 * time.Millisecond
 )
 
 // ScoreView displays player icons and scores for any number of players.
+//
+// When Match is set and has a target, each score is shown as "wins/needed".
 type ScoreView struct {
 	Widget
-	gameRef *game.Game
+	handle  *game.Handle
+	visuals PlayerVisuals
+	Match   *game.Match // Optional series the scores belong to
+
+	// Thinking is the AI player currently computing its move (nil if none).
+	Thinking *game.Player
 }
 
 // NewScoreView creates a score panel widget.
 //
 // The widget is anchored at the top-center by default and slightly offset downwards.
-func NewScoreView(g *game.Game, width, height float64, style utils.WidgetStyle) *ScoreView {
+// It draws the latest snapshot of the game behind h.
+func NewScoreView(h *game.Handle, visuals PlayerVisuals, width, height float64, style utils.WidgetStyle) *ScoreView {
 	bg := utils.CreateRoundedRect(int(width), int(height), scorePanelCornerRadiusPx, style.BackgroundNormal)
 
 	return &ScoreView{
@@ -77,7 +93,8 @@ func NewScoreView(g *game.Game, width, height float64, style utils.WidgetStyle)
 			OffsetY: scorePanelOffsetY,
 			Style:   style,
 		},
-		gameRef: g,
+		handle:  h,
+		visuals: visuals,
 	}
 }
 
@@ -96,7 +113,8 @@ func (sv *ScoreView) Draw(screen *ebiten.Image) {
 	op.GeoM.Translate(x, y)
 	screen.DrawImage(sv.image, op)
 
-	playerCount := len(sv.gameRef.Players)
+	snap := sv.handle.Snapshot()
+	playerCount := len(snap.Players)
 	if playerCount == 0 {
 		return
 	}
@@ -104,15 +122,16 @@ func (sv *ScoreView) Draw(screen *ebiten.Image) {
 	// Split the width into equal zones for each player.
 	zoneWidth := rect.Width / float64(playerCount)
 
-	for i, p := range sv.gameRef.Players {
+	for i, p := range snap.Players {
 		zoneX := x + float64(i)*zoneWidth
-		sv.drawPlayerZone(screen, p, zoneX, y, zoneWidth, rect.Height)
+		sv.drawPlayerZone(screen, snap, p, zoneX, y, zoneWidth, rect.Height)
 	}
 }
 
 // drawPlayerZone draws the icon + score of a single player inside its zone.
 func (sv *ScoreView) drawPlayerZone(
 	screen *ebiten.Image,
+	snap *game.Snapshot,
 	p *game.Player,
 	x, y, zoneWidth, zoneHeight float64,
 ) {
@@ -124,21 +143,23 @@ func (sv *ScoreView) drawPlayerZone(
 		iconSize = zoneHeight - (two * padding)
 	}
 
+	visual := sv.visuals.Of(p)
+
 	// Draw name.
 	if p.Name != "" {
 		nameOpts := &text.DrawOptions{}
 		nameOpts.PrimaryAlign = text.AlignCenter
 		nameOpts.SecondaryAlign = text.AlignCenter
-		nameOpts.ColorScale.ScaleWithColor(p.Color)
+		nameOpts.ColorScale.ScaleWithColor(visual.Color)
 		nameOpts.GeoM.Translate(x+zoneWidth*half, y+padding*zoneNamePaddingRatio+zoneNameYOffsetPx)
 		text.Draw(screen, p.Name, assets.NormalFont, nameOpts)
 	}
 
 	// Draw symbol.
-	if p.Symbol != nil && p.Symbol.Image != nil {
+	if img := visual.Symbol.Image; img != nil {
 		op := &ebiten.DrawImageOptions{}
 
-		w, h := p.Symbol.Image.Bounds().Dx(), p.Symbol.Image.Bounds().Dy()
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
 		scale := iconSize / float64(h)
 		if w > h {
 			scale = iconSize / float64(w)
@@ -151,18 +172,21 @@ func (sv *ScoreView) drawPlayerZone(
 		op.GeoM.Translate(iconX, iconY)
 
 		// Apply player color tint.
-		op.ColorScale.ScaleWithColor(p.Color)
+		op.ColorScale.ScaleWithColor(visual.Color)
 
 		// Dim non-active players.
-		if sv.gameRef.Current != p && sv.gameRef.State == game.PLAYING {
+		if snap.Current != p && snap.State == game.PLAYING {
 			op.ColorScale.ScaleAlpha(nonActiveAlphaScale)
 		}
 
-		screen.DrawImage(p.Symbol.Image, op)
+		screen.DrawImage(img, op)
 	}
 
-	// Draw score text.
+	// Draw score text (with the series target if any).
 	msg := fmt.Sprintf("%d", p.Points)
+	if sv.Match != nil && sv.Match.WinsNeeded() > 0 {
+		msg = fmt.Sprintf("%d/%d", p.Points, sv.Match.WinsNeeded())
+	}
 
 	opts := &text.DrawOptions{}
 	opts.PrimaryAlign = text.AlignCenter
@@ -175,4 +199,16 @@ func (sv *ScoreView) drawPlayerZone(
 	opts.GeoM.Translate(textX, textY)
 
 	text.Draw(screen, msg, assets.NormalFont, opts)
+
+	// Draw the thinking indicator, right-aligned on the score line.
+	if sv.Thinking != nil && sv.Thinking.ID == p.ID && snap.State == game.PLAYING {
+		dots := int(time.Now().UnixMilli()/thinkingDotPeriod.Milliseconds())%thinkingMaxDots + 1
+
+		dotOpts := &text.DrawOptions{}
+		dotOpts.PrimaryAlign = text.AlignEnd
+		dotOpts.SecondaryAlign = text.AlignCenter
+		dotOpts.ColorScale.ScaleWithColor(visual.Color)
+		dotOpts.GeoM.Translate(x+zoneWidth-padding, textY)
+		text.Draw(screen, strings.Repeat(thinkingDot, dots), assets.NormalFont, dotOpts)
+	}
 }